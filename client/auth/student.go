@@ -0,0 +1,178 @@
+// auth package is used for wrapping client of auth service with additional feature like retry, circuit breaker, etc ...
+// student.go is file to declare wrapper of AuthStudentService retrying idempotent call on another node
+
+package auth
+
+import (
+	"club/consul"
+	authproto "club/proto/golang/auth"
+	"club/tool/breaker"
+	"context"
+	"errors"
+	"github.com/micro/go-micro/v2/client"
+	microerrors "github.com/micro/go-micro/v2/errors"
+	log "github.com/micro/go-micro/v2/logger"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxAttempts         = 3
+	defaultBreakerThreshold    = 5
+	defaultBreakerOpenDuration = time.Second * 30
+)
+
+type nodeBreaker interface {
+	Allow(key string) bool
+	Success(key string)
+	Failure(key string) (tripped bool)
+	OpenDuration() time.Duration
+}
+
+// _retry embed AuthStudentService, so that only idempotent methods are overridden with retry logic
+type _retry struct {
+	authproto.AuthStudentService
+	consulAgent consul.Agent
+	service     consul.ServiceName
+	maxAttempts int
+	breaker     nodeBreaker
+}
+
+type FieldSetter func(*_retry)
+
+func Retry(srv authproto.AuthStudentService, setters ...FieldSetter) *_retry {
+	r := &_retry{
+		AuthStudentService: srv,
+		maxAttempts:        defaultMaxAttempts,
+		breaker:            breaker.New(defaultBreakerThreshold, defaultBreakerOpenDuration),
+	}
+	for _, setter := range setters {
+		setter(r)
+	}
+	return r
+}
+
+func ConsulAgent(ca consul.Agent) FieldSetter {
+	return func(r *_retry) {
+		r.consulAgent = ca
+	}
+}
+
+func Service(s consul.ServiceName) FieldSetter {
+	return func(r *_retry) {
+		r.service = s
+	}
+}
+
+func MaxAttempts(n int) FieldSetter {
+	return func(r *_retry) {
+		r.maxAttempts = n
+	}
+}
+
+// set threshold of consecutive failure to trip breaker of node & duration excluding tripped node
+func Breaker(threshold int, openDuration time.Duration) FieldSetter {
+	return func(r *_retry) {
+		r.breaker = breaker.New(threshold, openDuration)
+	}
+}
+
+func (r *_retry) GetStudentInformWithUUID(ctx context.Context, in *authproto.GetStudentInformWithUUIDRequest, opts ...client.CallOption) (out *authproto.GetStudentInformWithUUIDResponse, err error) {
+	err = r.callWithRetry(ctx, opts, func(callOpts []client.CallOption) (callErr error) {
+		out, callErr = r.AuthStudentService.GetStudentInformWithUUID(ctx, in, callOpts...)
+		return
+	})
+	return
+}
+
+func (r *_retry) GetStudentInformsWithUUIDs(ctx context.Context, in *authproto.GetStudentInformsWithUUIDsRequest, opts ...client.CallOption) (out *authproto.GetStudentInformsWithUUIDsResponse, err error) {
+	err = r.callWithRetry(ctx, opts, func(callOpts []client.CallOption) (callErr error) {
+		out, callErr = r.AuthStudentService.GetStudentInformsWithUUIDs(ctx, in, callOpts...)
+		return
+	})
+	return
+}
+
+// call fn with address in opts at first, and retry with another node selected in consul agent if retryable error returned
+func (r *_retry) callWithRetry(ctx context.Context, opts []client.CallOption, fn func([]client.CallOption) error) (err error) {
+	address := addressFromCallOptions(opts)
+
+	for attempt := 1; ; attempt++ {
+		switch {
+		case address == "":
+			break
+		case !r.breaker.Allow(address):
+			err = errors.New("circuit breaker of auth service node is open, address: " + address)
+		default:
+			// call is counted as outstanding request of node only while it is actually running
+			callOpts := append(append([]client.CallOption{}, opts...), client.WithAddress(address))
+			if r.consulAgent != nil {
+				r.consulAgent.StartCall(r.service, address)
+			}
+			startTime := time.Now()
+			err = fn(callOpts)
+			if r.consulAgent != nil {
+				r.consulAgent.ReportCallResult(r.service, address, time.Since(startTime), err)
+			}
+			if err == nil {
+				r.breaker.Success(address)
+				return
+			}
+			if !isRetryableError(err) {
+				// node responded with error of request itself, so that it is regarded as available in breaker
+				r.breaker.Success(address)
+				return
+			}
+			if r.breaker.Failure(address) && r.consulAgent != nil {
+				log.Infof("circuit breaker of auth service node is tripped, address: %s", address)
+				r.consulAgent.ExcludeNode(r.service, address, r.breaker.OpenDuration())
+			}
+		}
+
+		if attempt >= r.maxAttempts || r.consulAgent == nil {
+			return
+		}
+		// retry after deadline of ctx would fail anyway, so return error of last attempt
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			if err == nil {
+				err = context.DeadlineExceeded
+			}
+			return
+		}
+		selectedNode, selectErr := r.consulAgent.GetNextServiceNodeWithContext(ctx, r.service)
+		if selectErr != nil {
+			if err == nil {
+				err = selectErr
+			}
+			return
+		}
+		address = selectedNode.Address
+	}
+}
+
+// get address set with client.WithAddress in call options
+func addressFromCallOptions(opts []client.CallOption) string {
+	callOpts := client.CallOptions{}
+	for _, opt := range opts {
+		opt(&callOpts)
+	}
+	if len(callOpts.Address) == 0 {
+		return ""
+	}
+	return callOpts.Address[0]
+}
+
+// error that may succeed on another node like timeout, connection refused, etc ... is retryable
+func isRetryableError(err error) bool {
+	microErr, ok := err.(*microerrors.Error)
+	if !ok {
+		return true
+	}
+
+	switch microErr.Code {
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
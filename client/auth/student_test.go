@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"club/consul"
+	consulagent "club/consul/agent"
+	authproto "club/proto/golang/auth"
+	"context"
+	"github.com/micro/go-micro/v2/client"
+	microerrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const authService = consul.ServiceName("DMS.SMS.v1.service.auth")
+
+// fakeAuthStudent returns error set per node address, to check which node is called
+type fakeAuthStudent struct {
+	authproto.AuthStudentService
+	errByAddress    map[string]error
+	calledAddresses []string
+}
+
+func (f *fakeAuthStudent) GetStudentInformWithUUID(ctx context.Context, in *authproto.GetStudentInformWithUUIDRequest, opts ...client.CallOption) (*authproto.GetStudentInformWithUUIDResponse, error) {
+	address := addressFromCallOptions(opts)
+	f.calledAddresses = append(f.calledAddresses, address)
+	if err := f.errByAddress[address]; err != nil {
+		return nil, err
+	}
+	return &authproto.GetStudentInformWithUUIDResponse{Status: http.StatusOK}, nil
+}
+
+func Test_retry_GetStudentInformWithUUID(t *testing.T) {
+	timeoutErr := microerrors.Timeout("DMS.SMS.v1.service.auth", "request timeout")
+	notFoundErr := microerrors.NotFound("DMS.SMS.v1.service.auth", "not found")
+
+	tests := []struct {
+		ErrByAddress            map[string]error
+		Setters                 []FieldSetter
+		OnExpectMethods         func(*mock.Mock)
+		ExpectedCalledAddresses []string
+		ExpectedError           error
+	}{
+		{ // success case (first node)
+			OnExpectMethods: func(m *mock.Mock) {
+				m.On("StartCall", authService, "127.0.0.1:10101").Return()
+				m.On("ReportCallResult", authService, "127.0.0.1:10101", mock.Anything, nil).Return()
+			},
+			ExpectedCalledAddresses: []string{"127.0.0.1:10101"},
+		}, { // first node timeout -> retry on next node
+			ErrByAddress: map[string]error{"127.0.0.1:10101": timeoutErr},
+			OnExpectMethods: func(m *mock.Mock) {
+				m.On("StartCall", authService, "127.0.0.1:10101").Return()
+				m.On("ReportCallResult", authService, "127.0.0.1:10101", mock.Anything, timeoutErr).Return()
+				m.On("GetNextServiceNodeWithContext", mock.Anything, authService).Return(&registry.Node{Address: "127.0.0.1:10102"}, nil)
+				m.On("StartCall", authService, "127.0.0.1:10102").Return()
+				m.On("ReportCallResult", authService, "127.0.0.1:10102", mock.Anything, nil).Return()
+			},
+			ExpectedCalledAddresses: []string{"127.0.0.1:10101", "127.0.0.1:10102"},
+		}, { // not retryable error -> return without retry
+			ErrByAddress: map[string]error{"127.0.0.1:10101": notFoundErr},
+			OnExpectMethods: func(m *mock.Mock) {
+				m.On("StartCall", authService, "127.0.0.1:10101").Return()
+				m.On("ReportCallResult", authService, "127.0.0.1:10101", mock.Anything, notFoundErr).Return()
+			},
+			ExpectedCalledAddresses: []string{"127.0.0.1:10101"},
+			ExpectedError:           notFoundErr,
+		}, { // all attempts timeout -> trip breaker & exclude node in agent
+			ErrByAddress: map[string]error{"127.0.0.1:10101": timeoutErr},
+			Setters:      []FieldSetter{MaxAttempts(2), Breaker(1, time.Minute)},
+			OnExpectMethods: func(m *mock.Mock) {
+				m.On("StartCall", authService, "127.0.0.1:10101").Return()
+				m.On("ReportCallResult", authService, "127.0.0.1:10101", mock.Anything, timeoutErr).Return()
+				m.On("ExcludeNode", authService, "127.0.0.1:10101", time.Minute).Return()
+				m.On("GetNextServiceNodeWithContext", mock.Anything, authService).Return(&registry.Node{Address: "127.0.0.1:10101"}, nil)
+			},
+			ExpectedCalledAddresses: []string{"127.0.0.1:10101"},
+			ExpectedError:           timeoutErr,
+		},
+	}
+
+	for _, testCase := range tests {
+		mockStruct := new(mock.Mock)
+		testCase.OnExpectMethods(mockStruct)
+
+		fake := &fakeAuthStudent{errByAddress: testCase.ErrByAddress}
+		setters := append([]FieldSetter{ConsulAgent(consulagent.Mock(mockStruct)), Service(authService)}, testCase.Setters...)
+		_, err := Retry(fake, setters...).GetStudentInformWithUUID(context.Background(), &authproto.GetStudentInformWithUUIDRequest{}, client.WithAddress("127.0.0.1:10101"))
+
+		if testCase.ExpectedError == nil {
+			assert.Nil(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+		assert.Equal(t, testCase.ExpectedCalledAddresses, fake.calledAddresses)
+		mockStruct.AssertExpectations(t)
+	}
+}
+
+func Test_retry_GetStudentInformWithUUID_DeadlineExceeded(t *testing.T) {
+	timeoutErr := microerrors.Timeout("DMS.SMS.v1.service.auth", "request timeout")
+	mockStruct := new(mock.Mock)
+	mockStruct.On("StartCall", authService, "127.0.0.1:10101").Return()
+	mockStruct.On("ReportCallResult", authService, "127.0.0.1:10101", mock.Anything, timeoutErr).Return()
+
+	// deadline is passed while first node is called -> no retry on another node
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	fake := &fakeAuthStudent{errByAddress: map[string]error{"127.0.0.1:10101": timeoutErr}}
+	_, err := Retry(fake, ConsulAgent(consulagent.Mock(mockStruct)), Service(authService)).
+		GetStudentInformWithUUID(ctx, &authproto.GetStudentInformWithUUIDRequest{}, client.WithAddress("127.0.0.1:10101"))
+
+	assert.Equal(t, timeoutErr, err)
+	assert.Equal(t, []string{"127.0.0.1:10101"}, fake.calledAddresses)
+	mockStruct.AssertExpectations(t)
+	mockStruct.AssertNotCalled(t, "GetNextServiceNodeWithContext", mock.Anything, authService)
+}
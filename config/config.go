@@ -0,0 +1,272 @@
+// config.go is file to declare configuration of service, loaded from file, env & flags in that precedence
+// default value < JSON file (--config_file flag or CLUB_CONFIG_FILE env) < env < flags
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/micro/cli/v2"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HealthCheckModeHTTP = "http"
+	HealthCheckModeTTL  = "ttl"
+
+	MigrationModeAuto  = "auto"
+	MigrationModeCheck = "check"
+
+	StrategyRoundRobin       = "round_robin"
+	StrategyLeastOutstanding = "least_outstanding"
+	StrategyEWMALatency      = "ewma_latency"
+)
+
+// Config is configuration of service, field is set with env in `env` tag & flag in `flag` tag
+type Config struct {
+	// port of RPC server, random port in 10101 ~ 10200 is used if it is zero
+	Port            int      `json:"port" env:"PORT" flag:"port" validate:"min=0,max=65535"`
+	Standalone      bool     `json:"standalone" env:"STANDALONE" flag:"standalone"`
+	ShutdownTimeout Duration `json:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown_timeout" validate:"gt=0"`
+
+	Consul  ConsulConfig `json:"consul"`
+	Health  HealthConfig `json:"health"`
+	Jaeger  JaegerConfig `json:"jaeger"`
+	AWS     AWSConfig    `json:"aws"`
+	DB      DBConfig     `json:"db"`
+	SQS     SQSConfig    `json:"sqs"`
+	Auth    AuthConfig   `json:"auth"`
+	LogoDir string       `json:"logo_dir" env:"LOGO_DIR" flag:"logo_dir"`
+}
+
+type ConsulConfig struct {
+	Address       string `json:"address" env:"CONSUL_ADDRESS" flag:"consul_address"`
+	NodeCachePath string `json:"node_cache_path" env:"CONSUL_NODE_CACHE_PATH" flag:"consul_node_cache_path"`
+	DBKey         string `json:"db_key" env:"CONSUL_DB_KEY" flag:"consul_db_key" validate:"required"`
+	RuntimeKey    string `json:"runtime_key" env:"CONSUL_RUNTIME_KEY" flag:"consul_runtime_key" validate:"required"`
+	FlagsKey      string `json:"flags_key" env:"CONSUL_FLAGS_KEY" flag:"consul_flags_key" validate:"required"`
+	// strategy selecting node of service in consul agent, round robin is used as default
+	Strategy string `json:"strategy" env:"CONSUL_STRATEGY" flag:"consul_strategy" validate:"oneof=round_robin least_outstanding ewma_latency"`
+	// weight of new latency & latency regarded for failed call, used only in ewma_latency strategy
+	EWMADecay        float64  `json:"ewma_decay" env:"CONSUL_EWMA_DECAY" flag:"consul_ewma_decay" validate:"gt=0,lte=1"`
+	EWMAErrorPenalty Duration `json:"ewma_error_penalty" env:"CONSUL_EWMA_ERROR_PENALTY" flag:"consul_ewma_error_penalty" validate:"gt=0"`
+}
+
+type HealthConfig struct {
+	// mode of consul check, http check requesting health check server or ttl check as fallback
+	Mode string `json:"mode" env:"HEALTH_CHECK_MODE" flag:"health_check_mode" validate:"oneof=http ttl"`
+	// port of health check server, random port in 10201 ~ 10300 is used if it is zero
+	Port int `json:"port" env:"HEALTH_PORT" flag:"health_port" validate:"min=0,max=65535"`
+}
+
+type JaegerConfig struct {
+	Address string `json:"address" env:"JAEGER_ADDRESS" flag:"jaeger_address"`
+}
+
+type AWSConfig struct {
+	ID     string `json:"id" env:"SMS_AWS_ID" flag:"aws_id"`
+	Key    string `json:"key" env:"SMS_AWS_KEY" flag:"aws_key"`
+	Region string `json:"region" env:"SMS_AWS_REGION" flag:"aws_region"`
+	Bucket string `json:"bucket" env:"SMS_AWS_BUCKET" flag:"aws_bucket"`
+}
+
+type DBConfig struct {
+	Password string `json:"password" env:"DB_PASSWORD" flag:"db_password"`
+	// dialect of DSN used in standalone mode, sqlite DSN is file path of DB
+	Dialect string `json:"dialect" env:"DB_DIALECT" flag:"db_dialect" validate:"oneof=mysql sqlite"`
+	// apply pending migrations in startup or refuse to boot if there are pending migrations
+	MigrationMode string `json:"migration_mode" env:"DB_MIGRATION_MODE" flag:"db_migration_mode" validate:"oneof=auto check"`
+	// DSN used in standalone mode, instead of connection config in consul KV
+	DSN string `json:"dsn" env:"DB_DSN" flag:"dsn"`
+}
+
+type SQSConfig struct {
+	ConsulChangeQueue string `json:"consul_change_queue" env:"CHANGE_CONSUL_SQS_CLUB" flag:"consul_change_queue"`
+	DeadLetterQueue   string `json:"dead_letter_queue" env:"CHANGE_CONSUL_SQS_CLUB_DLQ" flag:"dead_letter_queue"`
+}
+
+type AuthConfig struct {
+	CanaryTag   string  `json:"canary_tag" env:"AUTH_CANARY_TAG" flag:"auth_canary_tag"`
+	CanaryShare float64 `json:"canary_share" env:"AUTH_CANARY_SHARE" flag:"auth_canary_share" validate:"min=0,max=1"`
+	// static addresses of auth service used in standalone mode
+	Nodes []string `json:"nodes" env:"AUTH_NODES" flag:"auth_nodes"`
+}
+
+// return configuration having default value
+func Default() Config {
+	return Config{
+		ShutdownTimeout: Duration(time.Second * 30),
+		Consul: ConsulConfig{
+			DBKey:            "db/club/local",
+			RuntimeKey:       "config/club/local",
+			FlagsKey:         "flags/club/local",
+			Strategy:         StrategyRoundRobin,
+			EWMADecay:        0.3,
+			EWMAErrorPenalty: Duration(time.Second * 3),
+		},
+		Health:  HealthConfig{Mode: HealthCheckModeHTTP},
+		DB:      DBConfig{Dialect: "mysql", MigrationMode: MigrationModeAuto},
+		LogoDir: "./logos",
+	}
+}
+
+// return flags registered in micro.Flags, so that flags can be parsed with micro service
+func CLIFlags() (flags []cli.Flag) {
+	flags = append(flags, &cli.StringFlag{Name: "config_file", Usage: "path of JSON configuration file"})
+	walkFields(reflect.ValueOf(&Config{}).Elem(), func(field reflect.StructField, _ reflect.Value) {
+		name, ok := field.Tag.Lookup("flag")
+		if !ok {
+			return
+		}
+		usage := fmt.Sprintf("overrides %s env", field.Tag.Get("env"))
+		if field.Type.Kind() == reflect.Bool {
+			flags = append(flags, &cli.BoolFlag{Name: name, Usage: usage})
+		} else {
+			flags = append(flags, &cli.StringFlag{Name: name, Usage: usage})
+		}
+	})
+	return
+}
+
+// load configuration with flags parsed in micro service, used in micro.Action
+func Load(c *cli.Context) (Config, error) {
+	path := os.Getenv("CLUB_CONFIG_FILE")
+	if c.IsSet("config_file") {
+		path = c.String("config_file")
+	}
+
+	return load(path, os.LookupEnv, func(name string) (string, bool) {
+		if !c.IsSet(name) {
+			return "", false
+		}
+		return fmt.Sprint(c.Value(name)), true
+	})
+}
+
+func load(path string, lookupEnv, lookupFlag func(string) (string, bool)) (conf Config, err error) {
+	conf = Default()
+
+	if path != "" {
+		b, readErr := ioutil.ReadFile(path)
+		if readErr != nil {
+			err = errors.New(fmt.Sprintf("unable to read configuration file, path: %s, err: %v", path, readErr))
+			return
+		}
+		if err = json.Unmarshal(b, &conf); err != nil {
+			err = errors.New(fmt.Sprintf("unable to unmarshal configuration file, path: %s, err: %v", path, err))
+			return
+		}
+	}
+
+	walkFields(reflect.ValueOf(&conf).Elem(), func(field reflect.StructField, value reflect.Value) {
+		for _, source := range []struct {
+			tag    string
+			lookup func(string) (string, bool)
+		}{{"env", lookupEnv}, {"flag", lookupFlag}} {
+			name, ok := field.Tag.Lookup(source.tag)
+			if !ok || err != nil {
+				continue
+			}
+			if str, exist := source.lookup(name); exist {
+				if setErr := setField(value, str); setErr != nil {
+					err = errors.New(fmt.Sprintf("invalid value of %s %s, err: %v", name, source.tag, setErr))
+				}
+			}
+		}
+	})
+	if err != nil {
+		return
+	}
+
+	err = conf.Validate()
+	return
+}
+
+// validate configuration with validator & check required fields in each mode
+func (c Config) Validate() error {
+	if err := validator.New().Struct(&c); err != nil {
+		return errors.New(fmt.Sprintf("invalid configuration, err: %v", err))
+	}
+
+	var required map[string]string
+	if c.Standalone {
+		required = map[string]string{"DB_DSN": c.DB.DSN}
+	} else {
+		required = map[string]string{
+			"CONSUL_ADDRESS": c.Consul.Address, "JAEGER_ADDRESS": c.Jaeger.Address, "DB_PASSWORD": c.DB.Password,
+			"SMS_AWS_ID": c.AWS.ID, "SMS_AWS_KEY": c.AWS.Key, "SMS_AWS_REGION": c.AWS.Region, "SMS_AWS_BUCKET": c.AWS.Bucket,
+			"CHANGE_CONSUL_SQS_CLUB": c.SQS.ConsulChangeQueue,
+		}
+	}
+
+	var missing []string
+	for name, value := range required {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return errors.New(fmt.Sprintf("please set %s in configuration", strings.Join(missing, ", ")))
+	}
+	return nil
+}
+
+// call fn with every field in nested struct, except nested struct field itself
+func walkFields(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			walkFields(value, fn)
+			continue
+		}
+		fn(field, value)
+	}
+}
+
+// set field value with string from env or flag
+func setField(v reflect.Value, str string) (err error) {
+	switch {
+	case v.Type() == reflect.TypeOf(Duration(0)):
+		var d time.Duration
+		if d, err = time.ParseDuration(str); err == nil {
+			v.SetInt(int64(d))
+		}
+	case v.Kind() == reflect.String:
+		v.SetString(str)
+	case v.Kind() == reflect.Int:
+		var i int
+		if i, err = strconv.Atoi(str); err == nil {
+			v.SetInt(int64(i))
+		}
+	case v.Kind() == reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(str); err == nil {
+			v.SetBool(b)
+		}
+	case v.Kind() == reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(str, 64); err == nil {
+			v.SetFloat(f)
+		}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		var elems []string
+		for _, elem := range strings.Split(str, ",") {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				elems = append(elems, elem)
+			}
+		}
+		v.Set(reflect.ValueOf(elems))
+	default:
+		err = errors.New(fmt.Sprintf("unsupported type of field, type: %s", v.Type()))
+	}
+	return
+}
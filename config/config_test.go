@@ -0,0 +1,88 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func lookupFrom(m map[string]string) func(string) (string, bool) {
+	return func(key string) (value string, exist bool) {
+		value, exist = m[key]
+		return
+	}
+}
+
+func Test_load(t *testing.T) {
+	file, err := ioutil.TempFile("", "club-config-*.json")
+	assert.Nil(t, err)
+	defer func() { _ = os.Remove(file.Name()) }()
+	_, err = file.WriteString(`{"port": 10101, "standalone": true, "db": {"dsn": "from-file"}, "auth": {"nodes": ["127.0.0.1:10001"]}}`)
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+
+	tests := []struct {
+		Path           string
+		Env, Flag      map[string]string
+		ExpectedConfig func(*Config)
+		ExpectedErr    bool
+	}{
+		{ // value in file is used
+			Path: file.Name(),
+			ExpectedConfig: func(c *Config) {
+				c.Port, c.Standalone, c.DB.DSN, c.Auth.Nodes = 10101, true, "from-file", []string{"127.0.0.1:10001"}
+			},
+		}, { // env is preferred to file
+			Path: file.Name(),
+			Env:  map[string]string{"DB_DSN": "from-env", "AUTH_NODES": "127.0.0.1:10001, 127.0.0.1:10002", "SHUTDOWN_TIMEOUT": "10s"},
+			ExpectedConfig: func(c *Config) {
+				c.Port, c.Standalone, c.DB.DSN, c.Auth.Nodes = 10101, true, "from-env", []string{"127.0.0.1:10001", "127.0.0.1:10002"}
+				c.ShutdownTimeout = Duration(time.Second * 10)
+			},
+		}, { // flag is preferred to env
+			Path: file.Name(),
+			Env:  map[string]string{"DB_DSN": "from-env", "PORT": "10102"},
+			Flag: map[string]string{"dsn": "from-flag"},
+			ExpectedConfig: func(c *Config) {
+				c.Port, c.Standalone, c.DB.DSN, c.Auth.Nodes = 10102, true, "from-flag", []string{"127.0.0.1:10001"}
+			},
+		}, { // strategy of consul agent & its parameters set with env
+			Path: file.Name(),
+			Env:  map[string]string{"CONSUL_STRATEGY": "ewma_latency", "CONSUL_EWMA_DECAY": "0.5", "CONSUL_EWMA_ERROR_PENALTY": "5s"},
+			ExpectedConfig: func(c *Config) {
+				c.Port, c.Standalone, c.DB.DSN, c.Auth.Nodes = 10101, true, "from-file", []string{"127.0.0.1:10001"}
+				c.Consul.Strategy, c.Consul.EWMADecay, c.Consul.EWMAErrorPenalty = StrategyEWMALatency, 0.5, Duration(time.Second*5)
+			},
+		}, { // unknown strategy of consul agent
+			Path:        file.Name(),
+			Flag:        map[string]string{"consul_strategy": "random"},
+			ExpectedErr: true,
+		}, { // required value in non-standalone mode not set
+			Env:         map[string]string{"CONSUL_ADDRESS": "localhost:8500"},
+			ExpectedErr: true,
+		}, { // invalid value in env
+			Path:        file.Name(),
+			Env:         map[string]string{"PORT": "not-number"},
+			ExpectedErr: true,
+		}, { // invalid value for validator
+			Path:        file.Name(),
+			Flag:        map[string]string{"health_check_mode": "grpc"},
+			ExpectedErr: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		conf, err := load(testCase.Path, lookupFrom(testCase.Env), lookupFrom(testCase.Flag))
+		if testCase.ExpectedErr {
+			assert.NotNil(t, err, "env: %v, flag: %v", testCase.Env, testCase.Flag)
+			continue
+		}
+		assert.Nil(t, err)
+
+		expected := Default()
+		testCase.ExpectedConfig(&expected)
+		assert.Equal(t, expected, conf)
+	}
+}
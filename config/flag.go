@@ -0,0 +1,193 @@
+// flag.go is file to declare feature flags loaded from consul KV, with in-memory override for tests
+// flag is boolean flag or percentage rollout flag, and rollout is decided consistently by UUID of caller
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/consul/api"
+	log "github.com/micro/go-micro/v2/logger"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// FlagGetter is interface to ask if feature flag is on for caller's UUID, used in handler
+type FlagGetter interface {
+	IsEnabled(name, uuid string) bool
+}
+
+// Flag is feature flag in flag document, flag not in document is regarded as off
+type Flag struct {
+	Enabled bool `json:"enabled"`
+	// percentage (0 ~ 100) of UUIDs for which flag is on, flag is on for all UUIDs if it is nil
+	Rollout *int `json:"rollout,omitempty" validate:"omitempty,min=0,max=100"`
+}
+
+// FlagDocument is JSON document saved in consul KV, key is name of flag
+type FlagDocument map[string]Flag
+
+// parse JSON document in consul KV & validate it
+func ParseFlagDocument(b []byte) (doc FlagDocument, err error) {
+	if err = json.Unmarshal(b, &doc); err != nil {
+		err = errors.New(fmt.Sprintf("unable to unmarshal flag document, err: %v", err))
+		return
+	}
+	v := validator.New()
+	for name, flag := range doc {
+		if err = v.Struct(&flag); err != nil {
+			err = errors.New(fmt.Sprintf("invalid flag in flag document, name: %s, err: %v", name, err))
+			return
+		}
+	}
+	return
+}
+
+type flags struct {
+	client   *api.Client
+	key      string
+	waitTime time.Duration
+
+	document  FlagDocument
+	overrides map[string]bool
+	docMutex  sync.RWMutex
+
+	watchCancel context.CancelFunc
+	watchGroup  sync.WaitGroup
+	watchMutex  sync.Mutex
+}
+
+type FlagsFieldSetter func(*flags)
+
+// return feature flags, all flags are off until flag document is loaded or overridden
+func Flags(setters ...FlagsFieldSetter) *flags {
+	f := &flags{
+		waitTime:  defaultWatchWaitTime,
+		document:  FlagDocument{},
+		overrides: map[string]bool{},
+	}
+	for _, setter := range setters {
+		setter(f)
+	}
+	return f
+}
+
+func FlagsConsulClient(c *api.Client) FlagsFieldSetter {
+	return func(f *flags) {
+		f.client = c
+	}
+}
+
+// set key of flag document in consul KV (ex. flags/club/local)
+func FlagsKey(key string) FlagsFieldSetter {
+	return func(f *flags) {
+		f.key = key
+	}
+}
+
+// check if flag is on for uuid, override is preferred to flag document
+func (f *flags) IsEnabled(name, uuid string) bool {
+	f.docMutex.RLock()
+	defer f.docMutex.RUnlock()
+
+	if on, exist := f.overrides[name]; exist {
+		return on
+	}
+
+	flag, exist := f.document[name]
+	if !exist || !flag.Enabled {
+		return false
+	}
+	if flag.Rollout == nil {
+		return true
+	}
+	return rolloutBucket(name, uuid) < *flag.Rollout
+}
+
+// override flag in memory regardless of flag document, used in test or emergency
+func (f *flags) Override(name string, on bool) {
+	f.docMutex.Lock()
+	defer f.docMutex.Unlock()
+	f.overrides[name] = on
+}
+
+func (f *flags) ClearOverride(name string) {
+	f.docMutex.Lock()
+	defer f.docMutex.Unlock()
+	delete(f.overrides, name)
+}
+
+// load flag document from consul KV once, all flags are off if key not exist
+func (f *flags) Load() (err error) {
+	kv, _, err := f.client.KV().Get(f.key, nil)
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to get %s KV from consul, err: %v", f.key, err))
+		return
+	}
+	return f.apply(kv)
+}
+
+// apply KV pair to flag document, invalid document is not applied
+func (f *flags) apply(kv *api.KVPair) error {
+	doc := FlagDocument{}
+	if kv != nil {
+		var err error
+		if doc, err = ParseFlagDocument(kv.Value); err != nil {
+			return err
+		}
+	}
+
+	f.docMutex.Lock()
+	f.document = doc
+	f.docMutex.Unlock()
+	return nil
+}
+
+// start goroutine watching flag document with consul blocking query, used with micro.AfterStart
+func (f *flags) StartWatching() (_ error) {
+	f.watchMutex.Lock()
+	defer f.watchMutex.Unlock()
+
+	if f.watchCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f.watchCancel = cancel
+	f.watchGroup.Add(1)
+	go func() {
+		defer f.watchGroup.Done()
+		watchKey(ctx, f.client, f.key, f.waitTime, func(kv *api.KVPair) {
+			if err := f.apply(kv); err != nil {
+				log.Errorf("keep previous feature flags, key: %s, err: %v", f.key, err)
+			}
+		})
+	}()
+	return
+}
+
+// stop goroutine started in StartWatching & wait for it to return
+func (f *flags) StopWatching() (_ error) {
+	f.watchMutex.Lock()
+	defer f.watchMutex.Unlock()
+
+	if f.watchCancel == nil {
+		return
+	}
+
+	f.watchCancel()
+	f.watchGroup.Wait()
+	f.watchCancel = nil
+	return
+}
+
+// return bucket (0 ~ 99) of uuid for flag, same uuid is always in same bucket of flag
+func rolloutBucket(name, uuid string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + ":" + uuid))
+	return int(h.Sum32() % 100)
+}
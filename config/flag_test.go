@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_flags_IsEnabled(t *testing.T) {
+	f := Flags()
+	assert.Nil(t, f.apply(&api.KVPair{Value: []byte(`{
+		"boolean-on": {"enabled": true},
+		"boolean-off": {"enabled": false},
+		"rollout-zero": {"enabled": true, "rollout": 0},
+		"rollout-full": {"enabled": true, "rollout": 100},
+		"rollout-half": {"enabled": true, "rollout": 50}
+	}`)}))
+
+	const uuid = "student-111111111111"
+	assert.True(t, f.IsEnabled("boolean-on", uuid))
+	assert.False(t, f.IsEnabled("boolean-off", uuid))
+	assert.False(t, f.IsEnabled("not-exist", uuid))
+	assert.False(t, f.IsEnabled("rollout-zero", uuid))
+	assert.True(t, f.IsEnabled("rollout-full", uuid))
+
+	// rollout is consistent for same uuid & spread over uuids
+	var onCount int
+	for i := 0; i < 1000; i++ {
+		studentUUID := fmt.Sprintf("student-%012d", i)
+		on := f.IsEnabled("rollout-half", studentUUID)
+		assert.Equal(t, on, f.IsEnabled("rollout-half", studentUUID))
+		if on {
+			onCount++
+		}
+	}
+	assert.InDelta(t, 500, onCount, 100)
+
+	// override is preferred to flag document
+	f.Override("boolean-on", false)
+	f.Override("not-exist", true)
+	assert.False(t, f.IsEnabled("boolean-on", uuid))
+	assert.True(t, f.IsEnabled("not-exist", uuid))
+	f.ClearOverride("boolean-on")
+	assert.True(t, f.IsEnabled("boolean-on", uuid))
+}
+
+func Test_ParseFlagDocument(t *testing.T) {
+	_, err := ParseFlagDocument([]byte(`{"rollout-over": {"enabled": true, "rollout": 101}}`))
+	assert.NotNil(t, err)
+	_, err = ParseFlagDocument([]byte(`{"not-flag": true}`))
+	assert.NotNil(t, err)
+	doc, err := ParseFlagDocument([]byte(`{"boolean-on": {"enabled": true}}`))
+	assert.Nil(t, err)
+	assert.True(t, doc["boolean-on"].Enabled)
+}
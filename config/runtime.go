@@ -0,0 +1,227 @@
+// config package is used for loading configuration of service, like runtime configuration in consul KV
+// runtime.go is file to declare runtime configuration, watched in consul KV and reloaded without redeploy
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/consul/api"
+	log "github.com/micro/go-micro/v2/logger"
+	"sync"
+	"time"
+)
+
+// RuntimeGetter is interface having typed getters of runtime configuration, used in handler
+type RuntimeGetter interface {
+	DefaultCountValue() int
+	AuthDialTimeout() time.Duration
+	AuthRequestTimeout() time.Duration
+	S3Bucket() string
+	DBMaxOpenConns() int
+	DBMaxIdleConns() int
+}
+
+// RuntimeDocument is JSON document saved in consul KV, field not in document is set with default value
+type RuntimeDocument struct {
+	DefaultCountValue  int      `json:"default_count_value" validate:"min=1,max=100"`
+	AuthDialTimeout    Duration `json:"auth_dial_timeout" validate:"gt=0"`
+	AuthRequestTimeout Duration `json:"auth_request_timeout" validate:"gt=0"`
+	S3Bucket           string   `json:"s3_bucket" validate:"required"`
+	DBMaxOpenConns     int      `json:"db_max_open_conns" validate:"min=1"`
+	DBMaxIdleConns     int      `json:"db_max_idle_conns" validate:"min=0,ltefield=DBMaxOpenConns"`
+}
+
+// return runtime document having value used before runtime configuration is added
+func DefaultRuntimeDocument() RuntimeDocument {
+	return RuntimeDocument{
+		DefaultCountValue:  10,
+		AuthDialTimeout:    Duration(time.Second * 2),
+		AuthRequestTimeout: Duration(time.Second * 3),
+		DBMaxOpenConns:     10,
+		DBMaxIdleConns:     2,
+	}
+}
+
+// Duration is time.Duration unmarshalled from string like "2s" in JSON
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return errors.New(fmt.Sprintf("duration must be string like \"2s\", err: %v", err))
+	}
+	parsed, err := time.ParseDuration(str)
+	if err != nil {
+		return errors.New(fmt.Sprintf("unable to parse duration, err: %v", err))
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// parse JSON document in consul KV on defaults & validate it
+func ParseRuntimeDocument(b []byte, defaults RuntimeDocument) (doc RuntimeDocument, err error) {
+	doc = defaults
+	if err = json.Unmarshal(b, &doc); err != nil {
+		err = errors.New(fmt.Sprintf("unable to unmarshal runtime document, err: %v", err))
+		return
+	}
+	if err = validator.New().Struct(&doc); err != nil {
+		err = errors.New(fmt.Sprintf("invalid runtime document, err: %v", err))
+		return
+	}
+	return
+}
+
+type runtime struct {
+	client   *api.Client
+	key      string
+	defaults RuntimeDocument
+	waitTime time.Duration
+
+	document  RuntimeDocument
+	docMutex  sync.RWMutex
+	onChanges []func(RuntimeDocument)
+
+	watchCancel context.CancelFunc
+	watchGroup  sync.WaitGroup
+	watchMutex  sync.Mutex
+}
+
+type RuntimeFieldSetter func(*runtime)
+
+func Runtime(setters ...RuntimeFieldSetter) *runtime {
+	r := &runtime{
+		defaults: DefaultRuntimeDocument(),
+		waitTime: defaultWatchWaitTime,
+	}
+	for _, setter := range setters {
+		setter(r)
+	}
+	r.document = r.defaults
+	return r
+}
+
+func ConsulClient(c *api.Client) RuntimeFieldSetter {
+	return func(r *runtime) {
+		r.client = c
+	}
+}
+
+// set key of runtime document in consul KV (ex. config/club/local)
+func Key(key string) RuntimeFieldSetter {
+	return func(r *runtime) {
+		r.key = key
+	}
+}
+
+// set default runtime document used if key not exist in consul KV or field not exist in document
+func Defaults(doc RuntimeDocument) RuntimeFieldSetter {
+	return func(r *runtime) {
+		r.defaults = doc
+	}
+}
+
+// register function called with new document whenever runtime document is changed
+func (r *runtime) OnChange(fn func(RuntimeDocument)) {
+	r.docMutex.Lock()
+	defer r.docMutex.Unlock()
+	r.onChanges = append(r.onChanges, fn)
+}
+
+// load runtime document from consul KV once, default document is used if key not exist
+func (r *runtime) Load() (err error) {
+	kv, _, err := r.client.KV().Get(r.key, nil)
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to get %s KV from consul, err: %v", r.key, err))
+		return
+	}
+	return r.apply(kv)
+}
+
+// apply KV pair to runtime document, invalid document is not applied
+func (r *runtime) apply(kv *api.KVPair) error {
+	doc := r.defaults
+	if kv != nil {
+		var err error
+		if doc, err = ParseRuntimeDocument(kv.Value, r.defaults); err != nil {
+			return err
+		}
+	}
+
+	r.docMutex.Lock()
+	changed := r.document != doc
+	r.document = doc
+	onChanges := r.onChanges
+	r.docMutex.Unlock()
+
+	if changed {
+		log.Infof("runtime configuration changed, key: %s, document: %+v", r.key, doc)
+		for _, fn := range onChanges {
+			fn(doc)
+		}
+	}
+	return nil
+}
+
+// start goroutine watching runtime document with consul blocking query, used with micro.AfterStart
+func (r *runtime) StartWatching() (_ error) {
+	r.watchMutex.Lock()
+	defer r.watchMutex.Unlock()
+
+	if r.watchCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.watchCancel = cancel
+	r.watchGroup.Add(1)
+	go r.watch(ctx)
+	return
+}
+
+// stop goroutine started in StartWatching & wait for it to return
+func (r *runtime) StopWatching() (_ error) {
+	r.watchMutex.Lock()
+	defer r.watchMutex.Unlock()
+
+	if r.watchCancel == nil {
+		return
+	}
+
+	r.watchCancel()
+	r.watchGroup.Wait()
+	r.watchCancel = nil
+	return
+}
+
+func (r *runtime) watch(ctx context.Context) {
+	defer r.watchGroup.Done()
+	watchKey(ctx, r.client, r.key, r.waitTime, func(kv *api.KVPair) {
+		if err := r.apply(kv); err != nil {
+			log.Errorf("keep previous runtime configuration, key: %s, err: %v", r.key, err)
+		}
+	})
+}
+
+func (r *runtime) current() RuntimeDocument {
+	r.docMutex.RLock()
+	defer r.docMutex.RUnlock()
+	return r.document
+}
+
+func (r *runtime) DefaultCountValue() int         { return r.current().DefaultCountValue }
+func (r *runtime) AuthDialTimeout() time.Duration { return time.Duration(r.current().AuthDialTimeout) }
+func (r *runtime) AuthRequestTimeout() time.Duration {
+	return time.Duration(r.current().AuthRequestTimeout)
+}
+func (r *runtime) S3Bucket() string    { return r.current().S3Bucket }
+func (r *runtime) DBMaxOpenConns() int { return r.current().DBMaxOpenConns }
+func (r *runtime) DBMaxIdleConns() int { return r.current().DBMaxIdleConns }
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_ParseRuntimeDocument(t *testing.T) {
+	defaults := DefaultRuntimeDocument()
+	defaults.S3Bucket = "dms-sms-local"
+
+	tests := []struct {
+		Document          string
+		ExpectedDocument  RuntimeDocument
+		ExpectedErrExists bool
+	}{
+		{ // empty document -> default value
+			Document:         `{}`,
+			ExpectedDocument: defaults,
+		}, { // partial document -> default value for fields not in document
+			Document: `{"default_count_value": 20, "auth_request_timeout": "5s"}`,
+			ExpectedDocument: RuntimeDocument{
+				DefaultCountValue:  20,
+				AuthDialTimeout:    defaults.AuthDialTimeout,
+				AuthRequestTimeout: Duration(time.Second * 5),
+				S3Bucket:           defaults.S3Bucket,
+				DBMaxOpenConns:     defaults.DBMaxOpenConns,
+				DBMaxIdleConns:     defaults.DBMaxIdleConns,
+			},
+		}, { // out of range count
+			Document:          `{"default_count_value": 0}`,
+			ExpectedErrExists: true,
+		}, { // invalid duration format
+			Document:          `{"auth_dial_timeout": 2}`,
+			ExpectedErrExists: true,
+		}, { // idle connection more than open connection
+			Document:          `{"db_max_open_conns": 5, "db_max_idle_conns": 10}`,
+			ExpectedErrExists: true,
+		}, { // empty bucket
+			Document:          `{"s3_bucket": ""}`,
+			ExpectedErrExists: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		doc, err := ParseRuntimeDocument([]byte(testCase.Document), defaults)
+		assert.Equalf(t, testCase.ExpectedErrExists, err != nil, "document: %s, err: %v", testCase.Document, err)
+		if !testCase.ExpectedErrExists {
+			assert.Equal(t, testCase.ExpectedDocument, doc)
+		}
+	}
+}
+
+func Test_runtime_Getters(t *testing.T) {
+	doc := DefaultRuntimeDocument()
+	doc.S3Bucket = "dms-sms-local"
+	r := Runtime(Defaults(doc))
+
+	var changed RuntimeDocument
+	r.OnChange(func(newDoc RuntimeDocument) { changed = newDoc })
+	assert.Equal(t, 10, r.DefaultCountValue())
+	assert.Equal(t, time.Second*2, r.AuthDialTimeout())
+
+	assert.Nil(t, r.apply(nil))
+	assert.Equal(t, RuntimeDocument{}, changed, "OnChange must not be called if document is not changed")
+
+	assert.Nil(t, r.apply(&api.KVPair{Value: []byte(`{"default_count_value": 30}`)}))
+	assert.Equal(t, 30, r.DefaultCountValue())
+	assert.Equal(t, 30, changed.DefaultCountValue)
+
+	// invalid document is not applied
+	assert.NotNil(t, r.apply(&api.KVPair{Value: []byte(`{"default_count_value": -1}`)}))
+	assert.Equal(t, 30, r.DefaultCountValue())
+}
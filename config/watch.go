@@ -0,0 +1,58 @@
+// watch.go is file to declare function watching key in consul KV with blocking query, used in runtime & flags
+
+package config
+
+import (
+	"context"
+	"github.com/hashicorp/consul/api"
+	log "github.com/micro/go-micro/v2/logger"
+	"time"
+)
+
+const (
+	defaultWatchWaitTime   = time.Minute * 5
+	defaultWatchMinBackoff = time.Second
+	defaultWatchMaxBackoff = time.Minute
+)
+
+// run consul blocking query about key until context is canceled, apply is called whenever KV pair changed
+// KV pair passed to apply is nil if key not exist, query is retried with backoff if it fails
+func watchKey(ctx context.Context, client *api.Client, key string, waitTime time.Duration, apply func(*api.KVPair)) {
+	var waitIndex uint64
+	backoff := defaultWatchMinBackoff
+
+	for {
+		opts := (&api.QueryOptions{WaitIndex: waitIndex, WaitTime: waitTime}).WithContext(ctx)
+		kv, meta, err := client.KV().Get(key, opts)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			log.Errorf("unable to watch key in consul KV, retry after %s, key: %s, err: %v", backoff, key, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > defaultWatchMaxBackoff {
+				backoff = defaultWatchMaxBackoff
+			}
+			continue
+		}
+		backoff = defaultWatchMinBackoff
+
+		// reset index if it goes backwards, see https://www.consul.io/api-docs/features/blocking
+		if meta.LastIndex < waitIndex {
+			waitIndex = 0
+			continue
+		}
+		if meta.LastIndex == waitIndex {
+			continue
+		}
+		waitIndex = meta.LastIndex
+
+		apply(kv)
+	}
+}
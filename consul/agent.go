@@ -4,8 +4,10 @@
 package consul
 
 import (
+	"context"
 	"github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/server"
+	"time"
 )
 
 type ServiceName string
@@ -16,6 +18,14 @@ type Agent interface {
 	// method to refresh specific service node list
 	ChangeServiceNodes(ServiceName) error // add in v.1.1.6
 	GetNextServiceNode(ServiceName) (*registry.Node, error)
+	// method to select node with routing rule of service, using header in context (ex. routing to canary nodes)
+	GetNextServiceNodeWithContext(context.Context, ServiceName) (*registry.Node, error)
+	// method to skip node with address in GetNextServiceNode during duration (ex. tripped by circuit breaker)
+	ExcludeNode(service ServiceName, address string, d time.Duration)
+	// method to report start of call to node selected in GetNextServiceNode, must be followed by ReportCallResult
+	StartCall(service ServiceName, address string)
+	// method to report outcome of call to node selected in GetNextServiceNode
+	ReportCallResult(service ServiceName, address string, latency time.Duration, err error)
 	ServiceNodeRegistry(server.Server) func() error   // add in v.1.1.6 (move from tool/closure/consul.go)
 	ServiceNodeDeregistry(server.Server) func() error // add in v.1.1.6 (move from tool/closure/consul.go)
 }
@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fake consul agent serving health query of catalog & registration API used in _default
+type fakeConsul struct {
+	server *httptest.Server
+	mutex  sync.Mutex
+
+	healthIndex   uint64
+	healthEntries []*api.ServiceEntry
+	healthChanged chan struct{}
+	queryIndexes  []uint64
+
+	services      map[string]*api.AgentServiceRegistration
+	checks        map[string]*api.AgentCheckRegistration
+	registerCount int
+}
+
+func newFakeConsul(t *testing.T) (*fakeConsul, *api.Client) {
+	f := &fakeConsul{
+		healthChanged: make(chan struct{}),
+		services:      map[string]*api.AgentServiceRegistration{},
+		checks:        map[string]*api.AgentCheckRegistration{},
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+
+	cli, err := api.NewClient(&api.Config{Address: f.server.URL})
+	assert.Nil(t, err)
+	return f, cli
+}
+
+func (f *fakeConsul) Close() {
+	f.server.Close()
+}
+
+// change result of health query, blocking queries waiting for index change are returned
+func (f *fakeConsul) setHealth(index uint64, entries []*api.ServiceEntry) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.healthIndex, f.healthEntries = index, entries
+	close(f.healthChanged)
+	f.healthChanged = make(chan struct{})
+}
+
+// remove all registered services & checks, like restarted consul agent
+func (f *fakeConsul) forgetRegistrations() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.services = map[string]*api.AgentServiceRegistration{}
+	f.checks = map[string]*api.AgentCheckRegistration{}
+}
+
+func (f *fakeConsul) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+		f.serveHealth(w, r)
+	case r.URL.Path == "/v1/agent/service/register":
+		registration := new(api.AgentServiceRegistration)
+		_ = json.NewDecoder(r.Body).Decode(registration)
+		f.mutex.Lock()
+		f.services[registration.ID] = registration
+		f.registerCount++
+		f.mutex.Unlock()
+	case r.URL.Path == "/v1/agent/check/register":
+		registration := new(api.AgentCheckRegistration)
+		_ = json.NewDecoder(r.Body).Decode(registration)
+		f.mutex.Lock()
+		f.checks[registration.ID] = registration
+		f.mutex.Unlock()
+	case strings.HasPrefix(r.URL.Path, "/v1/agent/service/deregister/"):
+		f.mutex.Lock()
+		delete(f.services, strings.TrimPrefix(r.URL.Path, "/v1/agent/service/deregister/"))
+		f.mutex.Unlock()
+	case strings.HasPrefix(r.URL.Path, "/v1/agent/check/deregister/"):
+		f.mutex.Lock()
+		delete(f.checks, strings.TrimPrefix(r.URL.Path, "/v1/agent/check/deregister/"))
+		f.mutex.Unlock()
+	case r.URL.Path == "/v1/agent/services":
+		f.mutex.Lock()
+		services := map[string]*api.AgentService{}
+		for id, registration := range f.services {
+			services[id] = &api.AgentService{ID: id, Service: registration.Name}
+		}
+		f.mutex.Unlock()
+		_ = json.NewEncoder(w).Encode(services)
+	case r.URL.Path == "/v1/agent/checks":
+		f.mutex.Lock()
+		checks := map[string]*api.AgentCheck{}
+		for id, registration := range f.checks {
+			checks[id] = &api.AgentCheck{CheckID: id, ServiceID: registration.ServiceID}
+		}
+		f.mutex.Unlock()
+		_ = json.NewEncoder(w).Encode(checks)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// blocking query is held until index of health changes, wait time passes or request is canceled
+func (f *fakeConsul) serveHealth(w http.ResponseWriter, r *http.Request) {
+	index, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+	wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+
+	f.mutex.Lock()
+	f.queryIndexes = append(f.queryIndexes, index)
+	changed := f.healthChanged
+	blocking := index != 0 && index == f.healthIndex
+	f.mutex.Unlock()
+
+	if blocking {
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.healthIndex, 10))
+	_ = json.NewEncoder(w).Encode(f.healthEntries)
+}
+
+// wait until condition is satisfied, fail test if it isn't satisfied in a second
+func waitUntil(t *testing.T, condition func() bool, message string) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.Fail(t, message)
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -5,10 +5,12 @@ package agent
 
 import (
 	"club/consul"
+	"context"
 	"github.com/hashicorp/consul/api"
 	"github.com/micro/go-micro/v2/client/selector"
 	"github.com/micro/go-micro/v2/registry"
 	"sync"
+	"time"
 )
 
 type _default struct {
@@ -20,6 +22,29 @@ type _default struct {
 	nodes     map[consul.ServiceName][]*registry.Node // change in v.1.1.6
 	services  []consul.ServiceName                    // add in v.1.1.6
 	nodeMutex sync.RWMutex                            // add in v.1.1.6
+
+	excluded     map[consul.ServiceName]map[string]time.Time
+	stats        *nodeStatsTable
+	canaryNext   map[consul.ServiceName]selector.Next
+	routingRules map[consul.ServiceName]RoutingRule
+	cachePath    string
+	stale        map[consul.ServiceName]bool
+
+	httpCheckPort           int
+	httpCheckPath           string
+	checkInterval           time.Duration
+	checkTimeout            time.Duration
+	deregisterCriticalAfter time.Duration
+
+	registrationCheckInterval time.Duration
+	registerCancel            context.CancelFunc
+	registerGroup             sync.WaitGroup
+	registerMutex             sync.Mutex
+
+	watchWaitTime time.Duration
+	watchCancel   context.CancelFunc
+	watchGroup    sync.WaitGroup
+	watchMutex    sync.Mutex
 }
 
 func Default(setters ...FieldSetter) *_default {
@@ -28,17 +53,33 @@ func Default(setters ...FieldSetter) *_default {
 
 func newDefault(setters ...FieldSetter) (h *_default) {
 	h = new(_default)
+	h.watchWaitTime = defaultWatchWaitTime
+	h.stats = newNodeStatsTable()
+	h.routingRules = map[consul.ServiceName]RoutingRule{}
+	h.checkInterval = defaultCheckInterval
+	h.checkTimeout = defaultCheckTimeout
+	h.deregisterCriticalAfter = defaultDeregisterCriticalAfter
+	h.registrationCheckInterval = defaultRegistrationCheckInterval
 	for _, setter := range setters {
 		setter(h)
 	}
 	h.next = map[consul.ServiceName]selector.Next{}
+	h.canaryNext = map[consul.ServiceName]selector.Next{}
 	h.nodes = map[consul.ServiceName][]*registry.Node{}
+	h.excluded = map[consul.ServiceName]map[string]time.Time{}
+	h.stale = map[consul.ServiceName]bool{}
 	h.nodeMutex = sync.RWMutex{}
 	return
 }
 
 type FieldSetter func(*_default)
 
+const (
+	defaultCheckInterval           = time.Second * 10
+	defaultCheckTimeout            = time.Second * 3
+	defaultDeregisterCriticalAfter = time.Minute
+)
+
 func Client(c *api.Client) FieldSetter {
 	return func(d *_default) {
 		d.client = c
@@ -56,3 +97,40 @@ func Services(s []consul.ServiceName) FieldSetter {
 		d.services = s
 	}
 }
+
+// set max wait time of consul blocking query used in StartWatching
+func WatchWaitTime(t time.Duration) FieldSetter {
+	return func(d *_default) {
+		d.watchWaitTime = t
+	}
+}
+
+// register consul HTTP check requesting health check endpoint with port & path instead of TTL check
+func HTTPCheck(port int, path string) FieldSetter {
+	return func(d *_default) {
+		d.httpCheckPort = port
+		d.httpCheckPath = path
+	}
+}
+
+// set interval & timeout of consul HTTP check
+func CheckInterval(interval, timeout time.Duration) FieldSetter {
+	return func(d *_default) {
+		d.checkInterval = interval
+		d.checkTimeout = timeout
+	}
+}
+
+// set duration after which service is deregistered by consul if HTTP check stays critical
+func DeregisterCriticalAfter(t time.Duration) FieldSetter {
+	return func(d *_default) {
+		d.deregisterCriticalAfter = t
+	}
+}
+
+// set interval to check if service & check are still registered in consul agent
+func RegistrationCheckInterval(t time.Duration) FieldSetter {
+	return func(d *_default) {
+		d.registrationCheckInterval = t
+	}
+}
@@ -0,0 +1,162 @@
+// default_cache.go is file to declare last-known-good node cache of default struct, persisted in local disk
+// node table is saved whenever live query succeeds, and loaded as stale table if consul is unreachable at boot
+
+package agent
+
+import (
+	"club/consul"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	log "github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/registry"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// whether node table of service is stale (1) or live (0), key is service name
+var staleMetrics = expvar.NewMap("consul_agent_stale_services")
+
+type nodeCache struct {
+	SavedAt time.Time                               `json:"saved_at"`
+	Nodes   map[consul.ServiceName][]*registry.Node `json:"nodes"`
+	// time when node list of each service is queried live, node list kept from previous cache has previous time
+	ServiceSavedAt map[consul.ServiceName]time.Time `json:"service_saved_at"`
+}
+
+// returns time when node list of service is saved, SavedAt is returned for cache file without ServiceSavedAt
+func (c nodeCache) savedAtOf(service consul.ServiceName) time.Time {
+	if savedAt, exist := c.ServiceSavedAt[service]; exist {
+		return savedAt
+	}
+	return c.SavedAt
+}
+
+// set file path to persist last successful node table, node cache is not used if path is empty
+func NodeCache(path string) FieldSetter {
+	return func(d *_default) {
+		d.cachePath = path
+	}
+}
+
+// set node list queried from consul successfully, stale node list is replaced, must be called with nodeMutex locked
+func (d *_default) setLiveServiceNodes(service consul.ServiceName, nodes []*registry.Node) (changed bool) {
+	wasStale := d.stale[service]
+	changed = d.setServiceNodes(service, nodes)
+
+	if wasStale {
+		delete(d.stale, service)
+		staleMetrics.Set(string(service), new(expvar.Int))
+		log.Infof("stale service nodes loaded from cache are replaced with live nodes, service: %s", service)
+	}
+
+	if changed || wasStale {
+		if err := d.saveNodeCache(); err != nil {
+			log.Errorf("unable to save node cache, path: %s, err: %v", d.cachePath, err)
+		}
+	}
+	return
+}
+
+// load node list of service from cache as stale nodes if there is no node list yet, must be called with nodeMutex locked
+// it returns true if node list is loaded, queryErr is error returned from live query, used in log
+func (d *_default) loadStaleServiceNodes(service consul.ServiceName, queryErr error) (loaded bool) {
+	if d.cachePath == "" {
+		return
+	}
+	if _, exist := d.nodes[service]; exist {
+		return
+	}
+
+	cache, err := d.readNodeCache()
+	if err != nil {
+		log.Errorf("unable to read node cache, path: %s, err: %v", d.cachePath, err)
+		return
+	}
+	nodes, exist := cache.Nodes[service]
+	if !exist {
+		return
+	}
+
+	d.setServiceNodes(service, nodes)
+	d.stale[service] = true
+	staleValue := new(expvar.Int)
+	staleValue.Set(1)
+	staleMetrics.Set(string(service), staleValue)
+	log.Warnf("unable to query service nodes in consul, use STALE nodes saved at %s, service: %s, err: %v",
+		cache.savedAtOf(service).Format(time.RFC3339), service, queryErr)
+
+	loaded = true
+	return
+}
+
+// check if node list of service is loaded from cache and not replaced with live nodes yet
+func (d *_default) IsStale(service consul.ServiceName) bool {
+	d.nodeMutex.RLock()
+	defer d.nodeMutex.RUnlock()
+	return d.stale[service]
+}
+
+// write live node table to cache file, must be called with nodeMutex locked
+// entries of service which is not live (stale or not queried yet) are kept as previous cache file with saved time
+func (d *_default) saveNodeCache() error {
+	if d.cachePath == "" {
+		return nil
+	}
+
+	now := time.Now()
+	cache := nodeCache{SavedAt: now, Nodes: map[consul.ServiceName][]*registry.Node{}, ServiceSavedAt: map[consul.ServiceName]time.Time{}}
+	for service, nodes := range d.nodes {
+		if !d.stale[service] {
+			cache.Nodes[service] = nodes
+			cache.ServiceSavedAt[service] = now
+		}
+	}
+
+	previous, err := d.readNodeCache()
+	if err != nil && !os.IsNotExist(err) {
+		log.Errorf("unable to read previous node cache, entries of stale services are not kept, path: %s, err: %v", d.cachePath, err)
+	}
+	for service, nodes := range previous.Nodes {
+		if _, live := cache.Nodes[service]; !live {
+			cache.Nodes[service] = nodes
+			cache.ServiceSavedAt[service] = previous.savedAtOf(service)
+		}
+	}
+
+	b, err := json.Marshal(cache)
+	if err != nil {
+		return errors.New(fmt.Sprintf("unable to marshal node cache, err: %v", err))
+	}
+
+	// write to temporary file & rename it, so that cache file is not broken when process is killed in writing
+	tmpFile, err := ioutil.TempFile(filepath.Dir(d.cachePath), filepath.Base(d.cachePath)+".tmp")
+	if err != nil {
+		return errors.New(fmt.Sprintf("unable to create temporary file, err: %v", err))
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write(b); err != nil {
+		_ = tmpFile.Close()
+		return errors.New(fmt.Sprintf("unable to write temporary file, err: %v", err))
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.New(fmt.Sprintf("unable to close temporary file, err: %v", err))
+	}
+	if err := os.Rename(tmpFile.Name(), d.cachePath); err != nil {
+		return errors.New(fmt.Sprintf("unable to rename temporary file, err: %v", err))
+	}
+	return nil
+}
+
+func (d *_default) readNodeCache() (cache nodeCache, err error) {
+	b, err := ioutil.ReadFile(d.cachePath)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &cache)
+	return
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"club/consul"
+	"github.com/hashicorp/consul/api"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_default_NodeCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consul-node-cache")
+	assert.Nil(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	cachePath := filepath.Join(dir, "nodes.json")
+
+	// save node table queried from consul successfully
+	saved := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}), NodeCache(cachePath))
+	saved.nodeMutex.Lock()
+	saved.setLiveServiceNodes(testService, testNodes)
+	saved.nodeMutex.Unlock()
+
+	// consul is unreachable in boot
+	unreachableCfg := api.DefaultConfig()
+	unreachableCfg.Address = "127.0.0.1:1"
+	unreachableCli, err := api.NewClient(unreachableCfg)
+	assert.Nil(t, err)
+
+	loaded := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}),
+		Client(unreachableCli), NodeCache(cachePath))
+	assert.Nil(t, loaded.ChangeAllServiceNodes())
+	assert.True(t, loaded.IsStale(testService))
+	assert.Equal(t, testNodes, loaded.nodes[testService])
+
+	selected, err := loaded.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	assert.Contains(t, []string{testNodes[0].Address, testNodes[1].Address}, selected.Address)
+
+	// stale node table is replaced as soon as live query succeeds
+	liveNodes := []*registry.Node{testNodes[1]}
+	loaded.nodeMutex.Lock()
+	loaded.setLiveServiceNodes(testService, liveNodes)
+	loaded.nodeMutex.Unlock()
+	assert.False(t, loaded.IsStale(testService))
+	assert.Equal(t, liveNodes, loaded.nodes[testService])
+
+	// without node cache, error is returned as before
+	noCache := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}), Client(unreachableCli))
+	assert.NotNil(t, noCache.ChangeAllServiceNodes())
+}
+
+func Test_default_NodeCache_KeepStaleService(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consul-node-cache")
+	assert.Nil(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	cachePath := filepath.Join(dir, "nodes.json")
+
+	const clubService = consul.ServiceName("DMS.SMS.v1.service.club")
+	clubNodes := []*registry.Node{{Id: "DMS.SMS.v1.service.club-1", Address: "127.0.0.1:10103"}}
+	services := []consul.ServiceName{testService, clubService}
+
+	saved := Default(Strategy(selector.RoundRobin), Services(services), NodeCache(cachePath))
+	saved.nodeMutex.Lock()
+	saved.setLiveServiceNodes(testService, testNodes)
+	saved.setLiveServiceNodes(clubService, clubNodes)
+	saved.nodeMutex.Unlock()
+	savedCache, err := saved.readNodeCache()
+	assert.Nil(t, err)
+
+	unreachableCfg := api.DefaultConfig()
+	unreachableCfg.Address = "127.0.0.1:1"
+	unreachableCli, err := api.NewClient(unreachableCfg)
+	assert.Nil(t, err)
+
+	// both services are loaded as stale, and only one of them is replaced with live nodes
+	loaded := Default(Strategy(selector.RoundRobin), Services(services), Client(unreachableCli), NodeCache(cachePath))
+	assert.Nil(t, loaded.ChangeAllServiceNodes())
+	liveNodes := []*registry.Node{testNodes[1]}
+	loaded.nodeMutex.Lock()
+	loaded.setLiveServiceNodes(testService, liveNodes)
+	loaded.nodeMutex.Unlock()
+	assert.False(t, loaded.IsStale(testService))
+	assert.True(t, loaded.IsStale(clubService))
+
+	// cache entry of stale service is kept with time when it is saved first
+	cache, err := loaded.readNodeCache()
+	assert.Nil(t, err)
+	assert.Equal(t, liveNodes, cache.Nodes[testService])
+	assert.Equal(t, clubNodes, cache.Nodes[clubService])
+	assert.True(t, cache.savedAtOf(clubService).Equal(savedCache.savedAtOf(clubService)))
+	assert.True(t, cache.savedAtOf(testService).After(savedCache.savedAtOf(testService)))
+
+	// stale service is still loaded from cache in next boot
+	reloaded := Default(Strategy(selector.RoundRobin), Services(services), Client(unreachableCli), NodeCache(cachePath))
+	assert.Nil(t, reloaded.ChangeAllServiceNodes())
+	assert.True(t, reloaded.IsStale(clubService))
+	assert.Equal(t, clubNodes, reloaded.nodes[clubService])
+}
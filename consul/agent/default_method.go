@@ -5,11 +5,13 @@ package agent
 
 import (
 	"club/consul"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/hashicorp/consul/api"
+	log "github.com/micro/go-micro/v2/logger"
 	"github.com/micro/go-micro/v2/registry"
-	"reflect"
+	"time"
 )
 
 const StatusMustBePassing = "Status==passing"
@@ -23,6 +25,9 @@ func (d *_default) ChangeAllServiceNodes() (err error) {
 		// when tmpErr is nil
 		if tmpErr := d.changeServiceNodes(service); tmpErr == nil {
 			continue
+		// when tmpErr is not nil, but last-known-good nodes are loaded from node cache
+		} else if d.loadStaleServiceNodes(service, tmpErr) {
+			continue
 		// when tmpErr is nil, but err is not nil
 		} else if err == nil {
 			err = tmpErr
@@ -56,22 +61,23 @@ func (d *_default) changeServiceNodes(service consul.ServiceName) error {
 		if err != nil {
 			return errors.New(fmt.Sprintf("unable to query service, err: %v", err))
 		}
-		var md = map[string]string{"CheckID": check.CheckID}
+		md := nodeMetadata(check.CheckID, as.Tags, as.Meta)
 		node := &registry.Node{Id: as.ID, Address: fmt.Sprintf("%s:%d", as.Address, as.Port), Metadata: md}
 		nodes = append(nodes, node)
 	}
 
-	if !reflect.DeepEqual(d.nodes[service], nodes) {
-		d.nodes[service] = nodes
-		d.next[service] = d.Strategy([]*registry.Service{{Nodes: nodes}})
-	}
-
+	d.setLiveServiceNodes(service, nodes)
 	return nil
 }
 
 // move from agent/default.go to agent/default_method.go
 // migrate change logic to changeServiceNodes method in v.1.1.6
 func (d *_default) GetNextServiceNode(service consul.ServiceName) (*registry.Node, error) {
+	return d.GetNextServiceNodeWithContext(context.Background(), service)
+}
+
+// select node with routing rule of service, header in ctx is used for routing to canary nodes
+func (d *_default) GetNextServiceNodeWithContext(ctx context.Context, service consul.ServiceName) (*registry.Node, error) {
 	d.nodeMutex.RLock()
 	defer d.nodeMutex.RUnlock()
 	
@@ -88,12 +94,54 @@ func (d *_default) GetNextServiceNode(service consul.ServiceName) (*registry.Nod
 		return nil, ErrAvailableNodeNotFound
 	}
 
-	selectedNode, err := d.next[service]()
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("unable to select node in selector, err: %v", err))
+	// node excluded by ExcludeNode (ex. tripped by circuit breaker) is filtered out before selection,
+	// so that strategy never selects excluded node having the lowest score because it doesn't receive any call
+	for _, group := range d.routeGroups(ctx, service) {
+		var available []*registry.Node
+		for _, node := range group.nodes {
+			if !d.isExcludedNode(service, node.Address) {
+				available = append(available, node)
+			}
+		}
+		if len(available) == 0 {
+			continue
+		}
+
+		next := group.next
+		if len(available) != len(group.nodes) {
+			next = d.Strategy([]*registry.Service{{Nodes: available}})
+		}
+		selectedNode, err := next()
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to select node in selector, err: %v", err))
+		}
+		return selectedNode, nil
 	}
 
-	return selectedNode, nil
+	return nil, ErrAvailableNodeNotFound
+}
+
+// report start of call to node selected in GetNextServiceNode, must be followed by ReportCallResult
+func (d *_default) StartCall(service consul.ServiceName, address string) {
+	d.stats.begin(address)
+}
+
+// exclude node with address from selection in GetNextServiceNode until duration passed
+func (d *_default) ExcludeNode(service consul.ServiceName, address string, duration time.Duration) {
+	d.nodeMutex.Lock()
+	defer d.nodeMutex.Unlock()
+
+	if _, exist := d.excluded[service]; !exist {
+		d.excluded[service] = map[string]time.Time{}
+	}
+	d.excluded[service][address] = time.Now().Add(duration)
+	log.Infof("exclude service node from selection, service: %s, address: %s, duration: %s", service, address, duration)
+}
+
+// check if node with address is excluded now, must be called with nodeMutex locked
+func (d *_default) isExcludedNode(service consul.ServiceName, address string) bool {
+	until, exist := d.excluded[service][address]
+	return exist && time.Now().Before(until)
 }
 
 // check if _default.services array contain srv parameter
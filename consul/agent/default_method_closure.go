@@ -4,6 +4,8 @@
 package agent
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/consul/api"
 	log "github.com/micro/go-micro/v2/logger"
@@ -11,65 +13,183 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	defaultRegistrationCheckInterval = time.Second * 30
+	defaultRegisterMinBackoff        = time.Second
+	defaultRegisterMaxBackoff        = time.Minute
 )
 
 // move from /tool/closure/consul.go in v.1.1.6
+// failure of registration doesn't kill process, it is retried in background loop started in this closure
 func (d *_default) ServiceNodeRegistry(s server.Server) func() error {
 	return func() (err error) {
-		port, err := getPortFromServerOption(s.Options())
+		service, check, err := d.newRegistration(s)
 		if err != nil {
-			log.Fatalf("unable to get port number from server option, err: %v", err)
+			return
 		}
-		localAddr, err := getLocalIP()
-		if err != nil {
-			log.Fatalf("unable to get local address, err: %v", err)
+
+		if regErr := d.register(service, check); regErr != nil {
+			log.Errorf("unable to register service in consul, retry in background, err: %v", regErr)
+		} else {
+			log.Infof("succeed to registry service and check to consul!! (service id: %s | checker id: %s)", service.ID, check.ID)
 		}
 
-		srvID := fmt.Sprintf("%s-%s", s.Options().Name, s.Options().Id)
-		err = d.client.Agent().ServiceRegister(&api.AgentServiceRegistration{
-			ID:      srvID,
-			Name:    s.Options().Name,
-			Port:    port,
-			Address: localAddr,
-		})
-		if err != nil {
-			log.Fatalf("unable to register service in consul, err: %v", err)
+		d.registerMutex.Lock()
+		defer d.registerMutex.Unlock()
+		if d.registerCancel == nil {
+			ctx, cancel := context.WithCancel(context.Background())
+			d.registerCancel = cancel
+			d.registerGroup.Add(1)
+			go d.keepRegistered(ctx, service, check)
+		}
+		return
+	}
+}
+
+// create registration of service & check with server options
+func (d *_default) newRegistration(s server.Server) (service *api.AgentServiceRegistration, check *api.AgentCheckRegistration, err error) {
+	port, err := getPortFromServerOption(s.Options())
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to get port number from server option, err: %v", err))
+		return
+	}
+	localAddr, err := getLocalIP()
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to get local address, err: %v", err))
+		return
+	}
+
+	srvID := fmt.Sprintf("%s-%s", s.Options().Name, s.Options().Id)
+	service = &api.AgentServiceRegistration{
+		ID:      srvID,
+		Name:    s.Options().Name,
+		Port:    port,
+		Address: localAddr,
+	}
+	check = &api.AgentCheckRegistration{
+		ID:                fmt.Sprintf("service:%s", srvID),
+		Name:              fmt.Sprintf("service '%s' check", s.Options().Name),
+		ServiceID:         srvID,
+		AgentServiceCheck: d.agentServiceCheck(s.Options().Name, localAddr),
+	}
+	return
+}
+
+// register service & check in consul agent
+func (d *_default) register(service *api.AgentServiceRegistration, check *api.AgentCheckRegistration) error {
+	if err := d.client.Agent().ServiceRegister(service); err != nil {
+		return errors.New(fmt.Sprintf("unable to register service in consul, err: %v", err))
+	}
+	if err := d.client.Agent().CheckRegister(check); err != nil {
+		return errors.New(fmt.Sprintf("unable to register check in consul, err: %v", err))
+	}
+	return nil
+}
+
+// check periodically if service & check are registered in consul agent (ex. forgotten after restart of agent)
+// and register them again with backoff if they are missing, until context is canceled
+func (d *_default) keepRegistered(ctx context.Context, service *api.AgentServiceRegistration, check *api.AgentCheckRegistration) {
+	defer d.registerGroup.Done()
+
+	wait := d.registrationCheckInterval
+	backoff := defaultRegisterMinBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		registered, err := d.isRegistered(service.ID, check.ID)
+		if err == nil && registered {
+			wait, backoff = d.registrationCheckInterval, defaultRegisterMinBackoff
+			continue
+		}
+		if err == nil {
+			log.Warnf("service or check is missing in consul agent, register again, service id: %s", service.ID)
+			err = d.register(service, check)
 		}
 
-		checkID := fmt.Sprintf("service:%s", srvID)
-		checkName := fmt.Sprintf("service '%s' check", s.Options().Name)
-		err = d.client.Agent().CheckRegister(&api.AgentCheckRegistration{
-			ID:                checkID,
-			Name:              checkName,
-			ServiceID:         srvID,
-			AgentServiceCheck: api.AgentServiceCheck{
-				Name:   s.Options().Name,
-				Status: "passing",
-				TTL:    "8640h",
-			},
-		})
 		if err != nil {
-			log.Fatalf("unable to register check in consul, err: %v", err)
+			log.Errorf("unable to keep service registered in consul, retry after %s, err: %v", backoff, err)
+			wait = backoff
+			if backoff *= 2; backoff > defaultRegisterMaxBackoff {
+				backoff = defaultRegisterMaxBackoff
+			}
+			continue
 		}
 
-		log.Infof("succeed to registry service and check to consul!! (service id: %s | checker id: %s)", srvID, checkID)
+		log.Infof("succeed to registry service and check to consul again!! (service id: %s | checker id: %s)", service.ID, check.ID)
+		wait, backoff = d.registrationCheckInterval, defaultRegisterMinBackoff
+	}
+}
+
+// check if service & check with id are registered in consul agent
+func (d *_default) isRegistered(serviceID, checkID string) (registered bool, err error) {
+	services, err := d.client.Agent().Services()
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to query services in consul agent, err: %v", err))
 		return
 	}
+	checks, err := d.client.Agent().Checks()
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to query checks in consul agent, err: %v", err))
+		return
+	}
+
+	_, serviceExist := services[serviceID]
+	_, checkExist := checks[checkID]
+	registered = serviceExist && checkExist
+	return
+}
+
+// return HTTP check of health check endpoint if it is set with HTTPCheck, otherwise TTL check as fallback
+func (d *_default) agentServiceCheck(name, localAddr string) api.AgentServiceCheck {
+	if d.httpCheckPort == 0 {
+		return api.AgentServiceCheck{
+			Name:   name,
+			Status: "passing",
+			TTL:    "8640h",
+		}
+	}
+
+	return api.AgentServiceCheck{
+		Name:                           name,
+		HTTP:                           fmt.Sprintf("http://%s:%d%s", localAddr, d.httpCheckPort, d.httpCheckPath),
+		Method:                         "GET",
+		Interval:                       d.checkInterval.String(),
+		Timeout:                        d.checkTimeout.String(),
+		DeregisterCriticalServiceAfter: d.deregisterCriticalAfter.String(),
+	}
 }
 
 // move from /tool/closure/consul.go in v.1.1.6
+// background loop started in ServiceNodeRegistry is stopped before deregistering
 func (d *_default) ServiceNodeDeregistry(s server.Server) func() error {
 	return func() (err error) {
+		d.registerMutex.Lock()
+		if d.registerCancel != nil {
+			d.registerCancel()
+			d.registerGroup.Wait()
+			d.registerCancel = nil
+		}
+		d.registerMutex.Unlock()
+
 		srvID := fmt.Sprintf("%s-%s", s.Options().Name, s.Options().Id)
 		err = d.client.Agent().ServiceDeregister(srvID)
 		if err != nil {
-			log.Fatalf("unable to deregister service in consul, err: %v", err)
+			err = errors.New(fmt.Sprintf("unable to deregister service in consul, err: %v", err))
+			return
 		}
 
 		checkID := fmt.Sprintf("service:%s", srvID)
 		err = d.client.Agent().CheckDeregister(checkID)
 		if err != nil {
-			log.Fatalf("unable to deregister check in consul, err: %v", err)
+			err = errors.New(fmt.Sprintf("unable to deregister check in consul, err: %v", err))
+			return
 		}
 
 		log.Infof("succeed to deregistry service and check to consul!! (service id: %s | checker id: %s)", srvID, checkID)
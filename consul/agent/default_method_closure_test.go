@@ -0,0 +1,123 @@
+package agent
+
+import (
+	"github.com/hashicorp/consul/api"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/server"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeServer struct {
+	server.Server
+}
+
+func (fakeServer) Options() server.Options {
+	return server.Options{
+		Name:    "DMS.SMS.v1.service.club",
+		Id:      "6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
+		Address: "[::]:10103",
+	}
+}
+
+const (
+	testServiceID = "DMS.SMS.v1.service.club-6b37b034-5f0b-4c9f-a03a-decbcb3799ef"
+	testCheckID   = "service:" + testServiceID
+)
+
+func Test_default_ServiceNodeRegistry_CheckDefinition(t *testing.T) {
+	tests := []struct {
+		Setters       []FieldSetter
+		ExpectedHTTP  string
+		ExpectedCheck api.AgentServiceCheck
+	}{
+		{ // HTTP check is not set -> TTL check as fallback
+			ExpectedCheck: api.AgentServiceCheck{
+				Status: "passing",
+				TTL:    "8640h",
+			},
+		}, { // HTTP check with default interval, timeout & deregister critical after
+			Setters:      []FieldSetter{HTTPCheck(10180, "/health")},
+			ExpectedHTTP: ":10180/health",
+			ExpectedCheck: api.AgentServiceCheck{
+				Method:                         "GET",
+				Interval:                       defaultCheckInterval.String(),
+				Timeout:                        defaultCheckTimeout.String(),
+				DeregisterCriticalServiceAfter: defaultDeregisterCriticalAfter.String(),
+			},
+		}, { // HTTP check with interval, timeout & deregister critical after set
+			Setters:      []FieldSetter{HTTPCheck(10180, "/health"), CheckInterval(time.Second*5, time.Second*2), DeregisterCriticalAfter(time.Minute * 2)},
+			ExpectedHTTP: ":10180/health",
+			ExpectedCheck: api.AgentServiceCheck{
+				Method:                         "GET",
+				Interval:                       "5s",
+				Timeout:                        "2s",
+				DeregisterCriticalServiceAfter: "2m0s",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		fake, cli := newFakeConsul(t)
+		d := Default(append(testCase.Setters, Strategy(selector.RoundRobin), Client(cli))...)
+
+		assert.Nil(t, d.ServiceNodeRegistry(fakeServer{})())
+
+		fake.mutex.Lock()
+		_, serviceExist := fake.services[testServiceID]
+		check, checkExist := fake.checks[testCheckID]
+		fake.mutex.Unlock()
+		assert.Truef(t, serviceExist, "service registration assertion error (test case: %v)", testCase)
+		assert.Truef(t, checkExist, "check registration assertion error (test case: %v)", testCase)
+		if checkExist {
+			assert.Equal(t, testServiceID, check.ServiceID)
+			assert.Equal(t, "service 'DMS.SMS.v1.service.club' check", check.Name)
+			// address of HTTP check depends on local address of host running test
+			assert.Equalf(t, testCase.ExpectedHTTP != "", strings.HasPrefix(check.HTTP, "http://"), "http check assertion error (test case: %v)", testCase)
+			assert.Truef(t, strings.HasSuffix(check.HTTP, testCase.ExpectedHTTP), "http check assertion error (test case: %v)", testCase)
+			check.HTTP = ""
+			assert.Equalf(t, testCase.ExpectedCheck, check.AgentServiceCheck, "check definition assertion error (test case: %v)", testCase)
+		}
+
+		assert.Nil(t, d.ServiceNodeDeregistry(fakeServer{})())
+		fake.mutex.Lock()
+		assert.Empty(t, fake.services, "service must be deregistered")
+		assert.Empty(t, fake.checks, "check must be deregistered")
+		fake.mutex.Unlock()
+		fake.Close()
+	}
+}
+
+func Test_default_ServiceNodeRegistry_KeepRegistered(t *testing.T) {
+	fake, cli := newFakeConsul(t)
+	defer fake.Close()
+
+	d := Default(Strategy(selector.RoundRobin), Client(cli), RegistrationCheckInterval(time.Millisecond*10))
+	assert.Nil(t, d.ServiceNodeRegistry(fakeServer{})())
+
+	// consul agent forgets registration (ex. restarted) -> service & check are registered again in background
+	fake.forgetRegistrations()
+	waitUntil(t, func() bool {
+		fake.mutex.Lock()
+		defer fake.mutex.Unlock()
+		_, serviceExist := fake.services[testServiceID]
+		_, checkExist := fake.checks[testCheckID]
+		return serviceExist && checkExist
+	}, "service & check must be registered again")
+
+	fake.mutex.Lock()
+	assert.Equal(t, 2, fake.registerCount, "service must not be registered again while it is registered")
+	fake.mutex.Unlock()
+
+	// background loop is stopped in ServiceNodeDeregistry, so that deregistered service isn't registered again
+	assert.Nil(t, d.ServiceNodeDeregistry(fakeServer{})())
+	time.Sleep(time.Millisecond * 50)
+
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	assert.Empty(t, fake.services)
+	assert.Empty(t, fake.checks)
+	assert.Equal(t, 2, fake.registerCount)
+}
@@ -0,0 +1,128 @@
+// default_method_watch.go is file to declare method of default struct watching service nodes with consul blocking query
+// node list of each service is changed as soon as health of service changed in consul, without SQS message or RPC
+
+package agent
+
+import (
+	"club/consul"
+	"context"
+	"fmt"
+	"github.com/hashicorp/consul/api"
+	log "github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/registry"
+	"time"
+)
+
+const (
+	defaultWatchWaitTime   = time.Minute * 5
+	defaultWatchMinBackoff = time.Second
+	defaultWatchMaxBackoff = time.Minute
+)
+
+// start goroutine running blocking query per service in _default.services, used with micro.AfterStart
+func (d *_default) StartWatching() (_ error) {
+	d.watchMutex.Lock()
+	defer d.watchMutex.Unlock()
+
+	if d.watchCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.watchCancel = cancel
+	for _, service := range d.services {
+		d.watchGroup.Add(1)
+		go d.watchServiceNodes(ctx, service)
+	}
+
+	log.Infof("start watching service nodes in consul!! (services: %v)", d.services)
+	return
+}
+
+// stop all goroutine started in StartWatching & wait for them to return
+func (d *_default) StopWatching() (_ error) {
+	d.watchMutex.Lock()
+	defer d.watchMutex.Unlock()
+
+	if d.watchCancel == nil {
+		return
+	}
+
+	d.watchCancel()
+	d.watchGroup.Wait()
+	d.watchCancel = nil
+
+	log.Info("stop watching service nodes in consul!!")
+	return
+}
+
+// run consul blocking query about health of service until context is canceled, retry with backoff if query fails
+func (d *_default) watchServiceNodes(ctx context.Context, service consul.ServiceName) {
+	defer d.watchGroup.Done()
+
+	var waitIndex uint64
+	backoff := defaultWatchMinBackoff
+
+	for {
+		opts := (&api.QueryOptions{WaitIndex: waitIndex, WaitTime: d.watchWaitTime}).WithContext(ctx)
+		entries, meta, err := d.client.Health().Service(string(service), "", true, opts)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			log.Errorf("unable to query health of service in consul, retry after %s, service: %s, err: %v", backoff, service, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > defaultWatchMaxBackoff {
+				backoff = defaultWatchMaxBackoff
+			}
+			continue
+		}
+		backoff = defaultWatchMinBackoff
+
+		// reset index if it goes backwards, see https://www.consul.io/api-docs/features/blocking
+		if meta.LastIndex < waitIndex {
+			waitIndex = 0
+			continue
+		}
+		if meta.LastIndex == waitIndex {
+			continue
+		}
+		waitIndex = meta.LastIndex
+
+		nodes := nodesFromServiceEntries(entries)
+		d.nodeMutex.Lock()
+		changed := d.setLiveServiceNodes(service, nodes)
+		d.nodeMutex.Unlock()
+
+		if changed {
+			log.Infof("service nodes changed by consul watch, service: %s, node count: %d", service, len(nodes))
+		}
+	}
+}
+
+// convert service entries returned from consul health query to registry nodes
+func nodesFromServiceEntries(entries []*api.ServiceEntry) (nodes []*registry.Node) {
+	for _, entry := range entries {
+		address := entry.Service.Address
+		if address == "" {
+			address = entry.Node.Address
+		}
+
+		var checkID string
+		for _, check := range entry.Checks {
+			if check.ServiceID == entry.Service.ID {
+				checkID = check.CheckID
+				break
+			}
+		}
+		md := nodeMetadata(checkID, entry.Service.Tags, entry.Service.Meta)
+		nodes = append(nodes, &registry.Node{Id: entry.Service.ID, Address: fmt.Sprintf("%s:%d", address, entry.Service.Port), Metadata: md})
+	}
+	return
+}
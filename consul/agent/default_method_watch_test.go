@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"club/consul"
+	"github.com/hashicorp/consul/api"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newServiceEntry(id string, port int) *api.ServiceEntry {
+	return &api.ServiceEntry{
+		Node:    &api.Node{Address: "127.0.0.1"},
+		Service: &api.AgentService{ID: id, Service: string(testService), Port: port},
+		Checks:  api.HealthChecks{{CheckID: "service:" + id, ServiceID: id, Status: api.HealthPassing}},
+	}
+}
+
+// returns addresses of nodes of service set in _default
+func nodeAddresses(d *_default, service consul.ServiceName) (addresses []string) {
+	d.nodeMutex.RLock()
+	defer d.nodeMutex.RUnlock()
+	for _, node := range d.nodes[service] {
+		addresses = append(addresses, node.Address)
+	}
+	return
+}
+
+func Test_default_StartWatching(t *testing.T) {
+	fake, cli := newFakeConsul(t)
+	defer fake.Close()
+	fake.setHealth(10, []*api.ServiceEntry{newServiceEntry("auth-1", 10101)})
+
+	d := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}), Client(cli), WatchWaitTime(time.Second))
+	assert.Nil(t, d.StartWatching())
+	defer func() { _ = d.StopWatching() }()
+
+	waitUntil(t, func() bool { return len(nodeAddresses(d, testService)) == 1 }, "nodes must be set with result of first query")
+	assert.Equal(t, []string{"127.0.0.1:10101"}, nodeAddresses(d, testService))
+
+	// index increased -> nodes are changed as soon as blocking query returns
+	fake.setHealth(11, []*api.ServiceEntry{newServiceEntry("auth-1", 10101), newServiceEntry("auth-2", 10102)})
+	waitUntil(t, func() bool { return len(nodeAddresses(d, testService)) == 2 }, "nodes must be changed when index increases")
+	assert.Equal(t, []string{"127.0.0.1:10101", "127.0.0.1:10102"}, nodeAddresses(d, testService))
+
+	// index goes backwards -> index is reset & nodes are queried again without blocking
+	fake.setHealth(5, []*api.ServiceEntry{newServiceEntry("auth-2", 10102)})
+	waitUntil(t, func() bool { return len(nodeAddresses(d, testService)) == 1 }, "nodes must be changed when index is reset")
+	assert.Equal(t, []string{"127.0.0.1:10102"}, nodeAddresses(d, testService))
+
+	waitUntil(t, func() bool { fake.mutex.Lock(); defer fake.mutex.Unlock(); return len(fake.queryIndexes) >= 5 }, "query must be blocked with reset index")
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	// each blocking query waits with last index returned from consul
+	assert.Equal(t, []uint64{0, 10, 11, 0, 5}, fake.queryIndexes[:5])
+}
+
+func Test_default_StopWatching(t *testing.T) {
+	fake, cli := newFakeConsul(t)
+	defer fake.Close()
+	fake.setHealth(10, []*api.ServiceEntry{newServiceEntry("auth-1", 10101)})
+
+	d := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}), Client(cli), WatchWaitTime(time.Minute))
+	assert.Nil(t, d.StartWatching())
+
+	// wait for goroutine to be blocked in query with index returned from first query
+	waitUntil(t, func() bool { fake.mutex.Lock(); defer fake.mutex.Unlock(); return len(fake.queryIndexes) == 2 }, "blocking query must be requested")
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- d.StopWatching() }()
+	select {
+	case err := <-stopped:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "StopWatching must cancel blocking query & wait for goroutine to return")
+	}
+
+	// goroutine doesn't query any more after StopWatching returns
+	fake.setHealth(11, []*api.ServiceEntry{newServiceEntry("auth-1", 10101), newServiceEntry("auth-2", 10102)})
+	time.Sleep(time.Millisecond * 50)
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	assert.Len(t, fake.queryIndexes, 2)
+	assert.Equal(t, []string{"127.0.0.1:10101"}, nodeAddresses(d, testService))
+}
@@ -0,0 +1,159 @@
+// default_routing.go is file to declare routing rule of default struct, used for canary deploy of service
+// nodes tagged with canary tag in consul are separated from stable nodes, and selected by share of traffic or header
+
+package agent
+
+import (
+	"club/consul"
+	"context"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"math/rand"
+	"reflect"
+	"strings"
+)
+
+// key of node metadata having consul service tags joined with comma
+const TagsMetadataKey = "Tags"
+
+// RoutingRule is rule to route request to canary nodes of service, set per consul.ServiceName
+type RoutingRule struct {
+	// consul service tag of canary nodes (ex. "v1.2.0"), nodes without this tag are regarded as stable nodes
+	CanaryTag string
+	// share of traffic (0 ~ 1) routed to canary nodes
+	Share float64
+	// request with header (micro metadata) of this key & value is always routed to canary nodes
+	Header      string
+	HeaderValue string
+}
+
+// set routing rule of service, can be used multiple times for different services
+func Routing(service consul.ServiceName, rule RoutingRule) FieldSetter {
+	return func(d *_default) {
+		d.routingRules[service] = rule
+	}
+}
+
+// change routing rule of service at runtime, selector of service is rebuilt with current node list
+func (d *_default) SetRoutingRule(service consul.ServiceName, rule RoutingRule) {
+	d.nodeMutex.Lock()
+	defer d.nodeMutex.Unlock()
+
+	d.routingRules[service] = rule
+	if nodes, exist := d.nodes[service]; exist {
+		d.next[service], d.canaryNext[service] = d.newServiceNext(service, nodes)
+	}
+}
+
+// remove routing rule of service at runtime, so that all nodes are regarded as stable nodes
+func (d *_default) RemoveRoutingRule(service consul.ServiceName) {
+	d.nodeMutex.Lock()
+	defer d.nodeMutex.Unlock()
+
+	delete(d.routingRules, service)
+	if nodes, exist := d.nodes[service]; exist {
+		d.next[service], d.canaryNext[service] = d.newServiceNext(service, nodes)
+	}
+}
+
+// set node list & selector of service if node list changed, must be called with nodeMutex locked
+func (d *_default) setServiceNodes(service consul.ServiceName, nodes []*registry.Node) (changed bool) {
+	if reflect.DeepEqual(d.nodes[service], nodes) {
+		return
+	}
+
+	d.nodes[service] = nodes
+	d.next[service], d.canaryNext[service] = d.newServiceNext(service, nodes)
+	changed = true
+	return
+}
+
+// create selector of stable nodes & canary nodes, each selector is nil if there is no node for it
+func (d *_default) newServiceNext(service consul.ServiceName, nodes []*registry.Node) (stable, canary selector.Next) {
+	stableNodes, canaryNodes := d.splitCanaryNodes(service, nodes)
+	if len(stableNodes) != 0 {
+		stable = d.Strategy([]*registry.Service{{Nodes: stableNodes}})
+	}
+	if len(canaryNodes) != 0 {
+		canary = d.Strategy([]*registry.Service{{Nodes: canaryNodes}})
+	}
+	return
+}
+
+// split nodes into stable nodes & canary nodes with routing rule of service, all nodes are stable if there is no rule
+func (d *_default) splitCanaryNodes(service consul.ServiceName, nodes []*registry.Node) (stableNodes, canaryNodes []*registry.Node) {
+	rule, exist := d.routingRules[service]
+	if !exist || rule.CanaryTag == "" {
+		stableNodes = nodes
+		return
+	}
+
+	for _, node := range nodes {
+		if nodeHasTag(node, rule.CanaryTag) {
+			canaryNodes = append(canaryNodes, node)
+		} else {
+			stableNodes = append(stableNodes, node)
+		}
+	}
+	return
+}
+
+// group of nodes selected with same selector, stable or canary
+type nodeGroup struct {
+	nodes []*registry.Node
+	next  selector.Next
+}
+
+// return node groups in order to be tried, another group of nodes is used as fallback, must be called with nodeMutex locked
+func (d *_default) routeGroups(ctx context.Context, service consul.ServiceName) (groups []nodeGroup) {
+	stableNodes, canaryNodes := d.splitCanaryNodes(service, d.nodes[service])
+	stable := nodeGroup{nodes: stableNodes, next: d.next[service]}
+	canary := nodeGroup{nodes: canaryNodes, next: d.canaryNext[service]}
+	if d.routeToCanary(ctx, service) {
+		stable, canary = canary, stable
+	}
+
+	for _, group := range []nodeGroup{stable, canary} {
+		if group.next != nil && len(group.nodes) != 0 {
+			groups = append(groups, group)
+		}
+	}
+	return
+}
+
+// decide if request should be routed to canary nodes with routing rule of service
+func (d *_default) routeToCanary(ctx context.Context, service consul.ServiceName) bool {
+	rule, exist := d.routingRules[service]
+	if !exist {
+		return false
+	}
+
+	if rule.Header != "" && ctx != nil {
+		if value, ok := metadata.Get(ctx, rule.Header); ok && value == rule.HeaderValue {
+			return true
+		}
+	}
+	return rule.Share > 0 && rand.Float64() < rule.Share
+}
+
+// check if node has consul service tag in metadata
+func nodeHasTag(node *registry.Node, tag string) bool {
+	for _, nodeTag := range strings.Split(node.Metadata[TagsMetadataKey], ",") {
+		if nodeTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// create node metadata with consul service tags & meta, CheckID is set with checkID parameter
+func nodeMetadata(checkID string, tags []string, meta map[string]string) map[string]string {
+	md := map[string]string{}
+	for key, value := range meta {
+		md[key] = value
+	}
+	md["CheckID"] = checkID
+	md[TagsMetadataKey] = strings.Join(tags, ",")
+	return md
+}
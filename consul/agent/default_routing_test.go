@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"club/consul"
+	"context"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	stableNode = &registry.Node{Id: "auth-1", Address: "127.0.0.1:10101", Metadata: nodeMetadata("service:auth-1", []string{"v1.1.0"}, nil)}
+	canaryNode = &registry.Node{Id: "auth-2", Address: "127.0.0.1:10102", Metadata: nodeMetadata("service:auth-2", []string{"v1.2.0", "canary"}, nil)}
+)
+
+func Test_default_GetNextServiceNodeWithContext(t *testing.T) {
+	tests := []struct {
+		Rule            *RoutingRule
+		Nodes           []*registry.Node
+		Header          map[string]string
+		ExpectedAddress string
+	}{
+		{ // no routing rule -> all nodes are stable nodes
+			Nodes:           []*registry.Node{canaryNode},
+			ExpectedAddress: canaryNode.Address,
+		}, { // share is zero -> stable node
+			Rule:            &RoutingRule{CanaryTag: "v1.2.0", Share: 0},
+			Nodes:           []*registry.Node{stableNode, canaryNode},
+			ExpectedAddress: stableNode.Address,
+		}, { // share is one -> canary node
+			Rule:            &RoutingRule{CanaryTag: "v1.2.0", Share: 1},
+			Nodes:           []*registry.Node{stableNode, canaryNode},
+			ExpectedAddress: canaryNode.Address,
+		}, { // request with canary header -> canary node
+			Rule:            &RoutingRule{CanaryTag: "v1.2.0", Header: "X-Canary", HeaderValue: "true"},
+			Nodes:           []*registry.Node{stableNode, canaryNode},
+			Header:          map[string]string{"X-Canary": "true"},
+			ExpectedAddress: canaryNode.Address,
+		}, { // request with different header value -> stable node
+			Rule:            &RoutingRule{CanaryTag: "v1.2.0", Header: "X-Canary", HeaderValue: "true"},
+			Nodes:           []*registry.Node{stableNode, canaryNode},
+			Header:          map[string]string{"X-Canary": "false"},
+			ExpectedAddress: stableNode.Address,
+		}, { // no canary node -> fallback to stable node
+			Rule:            &RoutingRule{CanaryTag: "v1.2.0", Share: 1},
+			Nodes:           []*registry.Node{stableNode},
+			ExpectedAddress: stableNode.Address,
+		}, { // no stable node -> fallback to canary node
+			Rule:            &RoutingRule{CanaryTag: "v1.2.0", Share: 0},
+			Nodes:           []*registry.Node{canaryNode},
+			ExpectedAddress: canaryNode.Address,
+		},
+	}
+
+	for _, testCase := range tests {
+		d := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}))
+		if testCase.Rule != nil {
+			d.SetRoutingRule(testService, *testCase.Rule)
+		}
+		d.setServiceNodes(testService, testCase.Nodes)
+
+		ctx := metadata.NewContext(context.Background(), testCase.Header)
+		for i := 0; i < 10; i++ {
+			selected, err := d.GetNextServiceNodeWithContext(ctx, testService)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.ExpectedAddress, selected.Address, "rule: %v, header: %v", testCase.Rule, testCase.Header)
+		}
+	}
+}
+
+func Test_default_SetRoutingRule(t *testing.T) {
+	d := Default(Strategy(selector.RoundRobin), Services([]consul.ServiceName{testService}))
+	d.setServiceNodes(testService, []*registry.Node{stableNode, canaryNode})
+
+	// selector is rebuilt with current node list when routing rule changed
+	d.SetRoutingRule(testService, RoutingRule{CanaryTag: "canary", Share: 1})
+	selected, err := d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	assert.Equal(t, canaryNode.Address, selected.Address)
+
+	d.SetRoutingRule(testService, RoutingRule{CanaryTag: "canary", Share: 0})
+	selected, err = d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	assert.Equal(t, stableNode.Address, selected.Address)
+}
@@ -0,0 +1,158 @@
+// default_strategy.go is file to declare node selection strategy using call outcome reported to default struct
+// strategies declared in this file return selector.Strategy, so that it can be used like selector.RoundRobin
+
+package agent
+
+import (
+	"club/consul"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/registry"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEWMADecay    = 0.3
+	defaultErrorPenalty = time.Second * 3
+)
+
+// statistics of calls to one node, updated with StartCall & ReportCallResult
+type nodeStats struct {
+	outstanding int64
+	ewma        float64 // exponentially weighted moving average of latency in nanosecond
+}
+
+type nodeStatsTable struct {
+	stats        map[string]*nodeStats
+	decay        float64
+	errorPenalty time.Duration
+	mutex        sync.Mutex
+}
+
+func newNodeStatsTable() *nodeStatsTable {
+	return &nodeStatsTable{
+		stats:        map[string]*nodeStats{},
+		decay:        defaultEWMADecay,
+		errorPenalty: defaultErrorPenalty,
+	}
+}
+
+// must be called with mutex locked
+func (t *nodeStatsTable) get(address string) *nodeStats {
+	if _, exist := t.stats[address]; !exist {
+		t.stats[address] = &nodeStats{}
+	}
+	return t.stats[address]
+}
+
+func (t *nodeStatsTable) begin(address string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.get(address).outstanding++
+}
+
+// finish call started in begin, failed call is regarded as latency of error penalty
+func (t *nodeStatsTable) finish(address string, latency time.Duration, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s := t.get(address)
+	if s.outstanding > 0 {
+		s.outstanding--
+	}
+	if err != nil && latency < t.errorPenalty {
+		latency = t.errorPenalty
+	}
+	if s.ewma == 0 {
+		s.ewma = float64(latency)
+	} else {
+		s.ewma = t.decay*float64(latency) + (1-t.decay)*s.ewma
+	}
+}
+
+// select node with the lowest score calculated by score function, tie is broken randomly
+func (t *nodeStatsTable) selectNode(nodes []*registry.Node, score func(*nodeStats) float64) *registry.Node {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.seedEWMA(nodes)
+	var selected []*registry.Node
+	var lowest float64
+	for _, node := range nodes {
+		nodeScore := score(t.get(node.Address))
+		switch {
+		case len(selected) == 0 || nodeScore < lowest:
+			selected = []*registry.Node{node}
+			lowest = nodeScore
+		case nodeScore == lowest:
+			selected = append(selected, node)
+		}
+	}
+	return selected[rand.Intn(len(selected))]
+}
+
+// node without reported latency is seeded with mean latency of other nodes, so that it doesn't win every selection with zero
+// must be called with mutex locked
+func (t *nodeStatsTable) seedEWMA(nodes []*registry.Node) {
+	var sum float64
+	var count int
+	for _, node := range nodes {
+		if s := t.get(node.Address); s.ewma != 0 {
+			sum += s.ewma
+			count++
+		}
+	}
+	if count == 0 {
+		return
+	}
+
+	for _, node := range nodes {
+		if s := t.get(node.Address); s.ewma == 0 {
+			s.ewma = sum / float64(count)
+		}
+	}
+}
+
+// set strategy selecting node with the least outstanding requests
+func LeastOutstanding() FieldSetter {
+	return func(d *_default) {
+		d.Strategy = d.statsStrategy(func(s *nodeStats) float64 {
+			return float64(s.outstanding)
+		})
+	}
+}
+
+// set strategy selecting node with the lowest EWMA latency weighted by outstanding requests
+// decay is weight of new latency in EWMA, failed call is regarded as latency of errorPenalty
+func EWMALatency(decay float64, errorPenalty time.Duration) FieldSetter {
+	return func(d *_default) {
+		d.stats.decay = decay
+		d.stats.errorPenalty = errorPenalty
+		d.Strategy = d.statsStrategy(func(s *nodeStats) float64 {
+			return s.ewma * float64(s.outstanding+1)
+		})
+	}
+}
+
+// returns selector.Strategy selecting node with score function using statistics in _default
+func (d *_default) statsStrategy(score func(*nodeStats) float64) selector.Strategy {
+	return func(services []*registry.Service) selector.Next {
+		var nodes []*registry.Node
+		for _, service := range services {
+			nodes = append(nodes, service.Nodes...)
+		}
+
+		return func() (*registry.Node, error) {
+			if len(nodes) == 0 {
+				return nil, selector.ErrNoneAvailable
+			}
+			return d.stats.selectNode(nodes, score), nil
+		}
+	}
+}
+
+// report outcome of call started with StartCall, used in strategies declared in this file
+func (d *_default) ReportCallResult(service consul.ServiceName, address string, latency time.Duration, err error) {
+	d.stats.finish(address, latency, err)
+}
@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"club/consul"
+	"errors"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const testService = consul.ServiceName("DMS.SMS.v1.service.auth")
+
+var testNodes = []*registry.Node{
+	{Id: "auth-1", Address: "127.0.0.1:10101"},
+	{Id: "auth-2", Address: "127.0.0.1:10102"},
+}
+
+func newDefaultWithNodes(setters ...FieldSetter) *_default {
+	d := Default(append(setters, Services([]consul.ServiceName{testService}))...)
+	d.nodes[testService] = testNodes
+	d.next[testService] = d.Strategy([]*registry.Service{{Nodes: testNodes}})
+	return d
+}
+
+func Test_default_LeastOutstanding(t *testing.T) {
+	d := newDefaultWithNodes(LeastOutstanding())
+
+	first, err := d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	d.StartCall(testService, first.Address)
+	second, err := d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	d.StartCall(testService, second.Address)
+	assert.NotEqual(t, first.Address, second.Address, "node with outstanding request must not be selected twice")
+
+	// finish request of first node, so that it has the least outstanding requests
+	d.ReportCallResult(testService, first.Address, time.Millisecond, nil)
+	for i := 0; i < 10; i++ {
+		selected, err := d.GetNextServiceNode(testService)
+		assert.Nil(t, err)
+		assert.Equal(t, first.Address, selected.Address)
+		d.StartCall(testService, selected.Address)
+		d.ReportCallResult(testService, selected.Address, time.Millisecond, nil)
+	}
+}
+
+func Test_default_EWMALatency(t *testing.T) {
+	d := newDefaultWithNodes(EWMALatency(0.5, time.Second*3))
+
+	d.StartCall(testService, testNodes[0].Address)
+	d.ReportCallResult(testService, testNodes[0].Address, time.Millisecond*10, nil)
+	d.StartCall(testService, testNodes[1].Address)
+	d.ReportCallResult(testService, testNodes[1].Address, time.Millisecond*500, nil)
+
+	for i := 0; i < 10; i++ {
+		selected, err := d.GetNextServiceNode(testService)
+		assert.Nil(t, err)
+		assert.Equal(t, testNodes[0].Address, selected.Address, "node with lower latency must be selected")
+		d.StartCall(testService, selected.Address)
+		d.ReportCallResult(testService, selected.Address, time.Millisecond*10, nil)
+	}
+
+	// failed calls are regarded as error penalty, so that traffic moves to another node
+	for i := 0; i < 3; i++ {
+		d.StartCall(testService, testNodes[0].Address)
+		d.ReportCallResult(testService, testNodes[0].Address, time.Millisecond, errors.New("connection refused"))
+	}
+	selected, err := d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	assert.Equal(t, testNodes[1].Address, selected.Address, "node returning error must not be selected")
+}
+
+func Test_default_StartCall(t *testing.T) {
+	d := newDefaultWithNodes(LeastOutstanding())
+
+	// selecting node without starting call must not be counted as outstanding request
+	for i := 0; i < 10; i++ {
+		_, err := d.GetNextServiceNode(testService)
+		assert.Nil(t, err)
+	}
+	for _, node := range testNodes {
+		assert.Equal(t, int64(0), d.stats.get(node.Address).outstanding)
+	}
+
+	d.StartCall(testService, testNodes[0].Address)
+	assert.Equal(t, int64(1), d.stats.get(testNodes[0].Address).outstanding)
+	d.ReportCallResult(testService, testNodes[0].Address, time.Millisecond, nil)
+	assert.Equal(t, int64(0), d.stats.get(testNodes[0].Address).outstanding)
+}
+
+func Test_default_EWMALatency_SeedNewNode(t *testing.T) {
+	d := newDefaultWithNodes(EWMALatency(0.5, time.Second*3))
+
+	d.StartCall(testService, testNodes[0].Address)
+	d.ReportCallResult(testService, testNodes[0].Address, time.Millisecond*10, nil)
+
+	// node without reported latency must not win every selection with zero score
+	_, err := d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	assert.Equal(t, d.stats.get(testNodes[0].Address).ewma, d.stats.get(testNodes[1].Address).ewma, "new node must be seeded with mean latency")
+
+	// once seeded node reports higher latency, node with lower latency must be selected
+	d.StartCall(testService, testNodes[1].Address)
+	d.ReportCallResult(testService, testNodes[1].Address, time.Millisecond*500, nil)
+	selected, err := d.GetNextServiceNode(testService)
+	assert.Nil(t, err)
+	assert.Equal(t, testNodes[0].Address, selected.Address)
+}
+
+func Test_default_ExcludeNode(t *testing.T) {
+	// excluded node has the lowest latency, so that it would be selected by strategy if it isn't filtered out
+	d := newDefaultWithNodes(EWMALatency(0.5, time.Second*3))
+	d.StartCall(testService, testNodes[0].Address)
+	d.ReportCallResult(testService, testNodes[0].Address, time.Millisecond, nil)
+	d.StartCall(testService, testNodes[1].Address)
+	d.ReportCallResult(testService, testNodes[1].Address, time.Millisecond*500, nil)
+	d.ExcludeNode(testService, testNodes[0].Address, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		selected, err := d.GetNextServiceNode(testService)
+		assert.Nil(t, err)
+		assert.Equal(t, testNodes[1].Address, selected.Address, "excluded node must not be selected")
+		d.StartCall(testService, selected.Address)
+		d.ReportCallResult(testService, selected.Address, time.Millisecond, nil)
+	}
+
+	d.ExcludeNode(testService, testNodes[1].Address, time.Minute)
+	_, err := d.GetNextServiceNode(testService)
+	assert.Equal(t, ErrAvailableNodeNotFound, err)
+}
@@ -5,9 +5,11 @@ package agent
 
 import (
 	"club/consul"
+	"context"
 	"github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/server"
 	"github.com/stretchr/testify/mock"
+	"time"
 )
 
 type _mock struct {
@@ -23,7 +25,7 @@ func (m _mock) ChangeAllServiceNodes() error {
 }
 
 func (m _mock) ChangeServiceNodes(service consul.ServiceName) error {
-	return m.mock.Called().Error(0)
+	return m.mock.Called(service).Error(0)
 }
 
 func (m _mock) GetNextServiceNode(service consul.ServiceName) (*registry.Node, error) {
@@ -31,6 +33,23 @@ func (m _mock) GetNextServiceNode(service consul.ServiceName) (*registry.Node, e
 	return args.Get(0).(*registry.Node), args.Error(1)
 }
 
+func (m _mock) GetNextServiceNodeWithContext(ctx context.Context, service consul.ServiceName) (*registry.Node, error) {
+	args := m.mock.Called(ctx, service)
+	return args.Get(0).(*registry.Node), args.Error(1)
+}
+
+func (m _mock) ExcludeNode(service consul.ServiceName, address string, d time.Duration) {
+	m.mock.Called(service, address, d)
+}
+
+func (m _mock) StartCall(service consul.ServiceName, address string) {
+	m.mock.Called(service, address)
+}
+
+func (m _mock) ReportCallResult(service consul.ServiceName, address string, latency time.Duration, err error) {
+	m.mock.Called(service, address, latency, err)
+}
+
 func (m _mock) ServiceNodeRegistry(server server.Server) func() error {
 	return m.mock.Called(server).Get(0).(func() error)
 }
@@ -0,0 +1,87 @@
+// static.go is file to declare agent selecting node in static node list without consul, used in standalone mode
+// node list never changes, so methods about consul (registry, node change, ...) do nothing
+
+package agent
+
+import (
+	"club/consul"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/server"
+	"time"
+)
+
+type _static struct {
+	nodes map[consul.ServiceName][]*registry.Node
+	next  map[consul.ServiceName]selector.Next
+}
+
+type StaticFieldSetter func(*_static)
+
+func Static(setters ...StaticFieldSetter) *_static {
+	s := &_static{
+		nodes: map[consul.ServiceName][]*registry.Node{},
+		next:  map[consul.ServiceName]selector.Next{},
+	}
+	for _, setter := range setters {
+		setter(s)
+	}
+	return s
+}
+
+// set static node addresses of service, node is selected in round robin
+func StaticNodes(service consul.ServiceName, addresses ...string) StaticFieldSetter {
+	return func(s *_static) {
+		var nodes []*registry.Node
+		for index, address := range addresses {
+			nodes = append(nodes, &registry.Node{Id: fmt.Sprintf("%s-static-%d", service, index), Address: address})
+		}
+		s.nodes[service] = nodes
+		s.next[service] = selector.RoundRobin([]*registry.Service{{Nodes: nodes}})
+	}
+}
+
+func (s *_static) ChangeAllServiceNodes() error {
+	return nil
+}
+
+func (s *_static) ChangeServiceNodes(consul.ServiceName) error {
+	return nil
+}
+
+func (s *_static) GetNextServiceNode(service consul.ServiceName) (*registry.Node, error) {
+	return s.GetNextServiceNodeWithContext(context.Background(), service)
+}
+
+func (s *_static) GetNextServiceNodeWithContext(_ context.Context, service consul.ServiceName) (*registry.Node, error) {
+	next, exist := s.next[service]
+	if !exist {
+		return nil, ErrUndefinedService
+	}
+	if len(s.nodes[service]) == 0 {
+		return nil, ErrAvailableNodeNotFound
+	}
+
+	selectedNode, err := next()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to select node in selector, err: %v", err))
+	}
+	return selectedNode, nil
+}
+
+func (s *_static) ExcludeNode(consul.ServiceName, string, time.Duration) {}
+
+func (s *_static) StartCall(consul.ServiceName, string) {}
+
+func (s *_static) ReportCallResult(consul.ServiceName, string, time.Duration, error) {}
+
+func (s *_static) ServiceNodeRegistry(server.Server) func() error {
+	return func() error { return nil }
+}
+
+func (s *_static) ServiceNodeDeregistry(server.Server) func() error {
+	return func() error { return nil }
+}
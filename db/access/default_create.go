@@ -1,28 +1,31 @@
 package access
 
-import "club/model"
+import (
+	"club/model"
+	"context"
+)
 
-func (d *_default) CreateClub(club *model.Club) (*model.Club, error) {
-	err := d.tx.Create(club).Error
+func (d *_default) CreateClub(ctx context.Context, club *model.Club) (*model.Club, error) {
+	err := d.tx.WithContext(ctx).Create(club).Error
 	return club, err
 }
 
-func (d *_default) CreateClubInform(inform *model.ClubInform) (*model.ClubInform, error) {
-	err := d.tx.Create(inform).Error
+func (d *_default) CreateClubInform(ctx context.Context, inform *model.ClubInform) (*model.ClubInform, error) {
+	err := d.tx.WithContext(ctx).Create(inform).Error
 	return inform, err
 }
 
-func (d *_default) CreateClubMember(member *model.ClubMember) (*model.ClubMember, error) {
-	err := d.tx.Create(member).Error
+func (d *_default) CreateClubMember(ctx context.Context, member *model.ClubMember) (*model.ClubMember, error) {
+	err := d.tx.WithContext(ctx).Create(member).Error
 	return member, err
 }
 
-func (d *_default) CreateRecruitment(recruitment *model.ClubRecruitment) (*model.ClubRecruitment, error) {
-	err := d.tx.Create(recruitment).Error
+func (d *_default) CreateRecruitment(ctx context.Context, recruitment *model.ClubRecruitment) (*model.ClubRecruitment, error) {
+	err := d.tx.WithContext(ctx).Create(recruitment).Error
 	return recruitment, err
 }
 
-func (d *_default) CreateRecruitMember(member *model.RecruitMember) (*model.RecruitMember, error) {
-	err := d.tx.Create(member).Error
+func (d *_default) CreateRecruitMember(ctx context.Context, member *model.RecruitMember) (*model.RecruitMember, error) {
+	err := d.tx.WithContext(ctx).Create(member).Error
 	return member, err
 }
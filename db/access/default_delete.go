@@ -1,44 +1,47 @@
 package access
 
-import "club/model"
+import (
+	"club/model"
+	"context"
+)
 
-func (d *_default) DeleteClub(clubUUID string) (err error, rowsAffected int64) {
-	deleteResult := d.tx.Where("uuid = ?", clubUUID).Delete(&model.Club{})
+func (d *_default) DeleteClub(ctx context.Context, clubUUID string) (err error, rowsAffected int64) {
+	deleteResult := d.tx.WithContext(ctx).Where("uuid = ?", clubUUID).Delete(&model.Club{})
 	err = deleteResult.Error
 	rowsAffected = deleteResult.RowsAffected
 	return
 }
 
-func (d *_default) DeleteClubInform(clubUUID string) (err error, rowsAffected int64) {
-	deleteResult := d.tx.Where("club_uuid = ?", clubUUID).Delete(&model.ClubInform{})
+func (d *_default) DeleteClubInform(ctx context.Context, clubUUID string) (err error, rowsAffected int64) {
+	deleteResult := d.tx.WithContext(ctx).Where("club_uuid = ?", clubUUID).Delete(&model.ClubInform{})
 	err = deleteResult.Error
 	rowsAffected = deleteResult.RowsAffected
 	return
 }
 
-func (d *_default) DeleteClubMember(clubUUID, studentUUID string) (err error, rowsAffected int64) {
-	deleteResult := d.tx.Where("club_uuid = ? AND student_uuid = ?", clubUUID, studentUUID).Delete(&model.ClubMember{})
+func (d *_default) DeleteClubMember(ctx context.Context, clubUUID, studentUUID string) (err error, rowsAffected int64) {
+	deleteResult := d.tx.WithContext(ctx).Where("club_uuid = ? AND student_uuid = ?", clubUUID, studentUUID).Delete(&model.ClubMember{})
 	err = deleteResult.Error
 	rowsAffected = deleteResult.RowsAffected
 	return
 }
 
-func (d *_default) DeleteAllClubMembers(clubUUID string) (err error, rowsAffected int64) {
-	deleteResult := d.tx.Where("club_uuid = ?", clubUUID).Delete(&model.ClubMember{})
+func (d *_default) DeleteAllClubMembers(ctx context.Context, clubUUID string) (err error, rowsAffected int64) {
+	deleteResult := d.tx.WithContext(ctx).Where("club_uuid = ?", clubUUID).Delete(&model.ClubMember{})
 	err = deleteResult.Error
 	rowsAffected = deleteResult.RowsAffected
 	return
 }
 
-func (d *_default) DeleteRecruitment(recruitUUID string) (err error, rowsAffected int64) {
-	deleteResult := d.tx.Where("uuid = ?", recruitUUID).Delete(&model.ClubRecruitment{})
+func (d *_default) DeleteRecruitment(ctx context.Context, recruitUUID string) (err error, rowsAffected int64) {
+	deleteResult := d.tx.WithContext(ctx).Where("uuid = ?", recruitUUID).Delete(&model.ClubRecruitment{})
 	err = deleteResult.Error
 	rowsAffected = deleteResult.RowsAffected
 	return
 }
 
-func (d *_default) DeleteAllRecruitMember(recruitUUID string) (err error, rowsAffected int64) {
-	deleteResult := d.tx.Where("recruitment_uuid = ?", recruitUUID).Delete(&model.RecruitMember{})
+func (d *_default) DeleteAllRecruitMember(ctx context.Context, recruitUUID string) (err error, rowsAffected int64) {
+	deleteResult := d.tx.WithContext(ctx).Where("recruitment_uuid = ?", recruitUUID).Delete(&model.RecruitMember{})
 	err = deleteResult.Error
 	rowsAffected = deleteResult.RowsAffected
 	return
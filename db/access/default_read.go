@@ -2,13 +2,15 @@ package access
 
 import (
 	"club/model"
+	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
-func (d *_default) GetClubWithClubUUID(clubUUID string) (club *model.Club, err error) {
+func (d *_default) GetClubWithClubUUID(ctx context.Context, clubUUID string) (club *model.Club, err error) {
 	club = new(model.Club)
-	selectResult := d.tx.Where("uuid = ?", clubUUID).Find(club)
+	selectResult := d.tx.WithContext(ctx).Where("uuid = ?", clubUUID).Find(club)
 	err = selectResult.Error
 	if selectResult.RowsAffected == 0 && err == nil {
 		err = gorm.ErrRecordNotFound
@@ -16,9 +18,15 @@ func (d *_default) GetClubWithClubUUID(clubUUID string) (club *model.Club, err e
 	return
 }
 
-func (d *_default) GetClubWithLeaderUUID(leaderUUID string) (club *model.Club, err error) {
+// select club with locking its row until transaction ends, so that check & write about club in transaction is serialized
+// SQLite doesn't support SELECT FOR UPDATE, but writing transactions are already serialized with single connection
+func (d *_default) GetClubWithClubUUIDForUpdate(ctx context.Context, clubUUID string) (club *model.Club, err error) {
 	club = new(model.Club)
-	selectResult := d.tx.Where("leader_uuid = ?", leaderUUID).Find(club)
+	selectTx := d.tx.WithContext(ctx)
+	if selectTx.Dialector.Name() != "sqlite" {
+		selectTx = selectTx.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	selectResult := selectTx.Where("uuid = ?", clubUUID).Find(club)
 	err = selectResult.Error
 	if selectResult.RowsAffected == 0 && err == nil {
 		err = gorm.ErrRecordNotFound
@@ -26,11 +34,21 @@ func (d *_default) GetClubWithLeaderUUID(leaderUUID string) (club *model.Club, e
 	return
 }
 
-func (d *_default) GetCurrentRecruitmentWithClubUUID(clubUUID string) (recruit *model.ClubRecruitment, err error) {
+func (d *_default) GetClubWithLeaderUUID(ctx context.Context, leaderUUID string) (club *model.Club, err error) {
+	club = new(model.Club)
+	selectResult := d.tx.WithContext(ctx).Where("leader_uuid = ?", leaderUUID).Find(club)
+	err = selectResult.Error
+	if selectResult.RowsAffected == 0 && err == nil {
+		err = gorm.ErrRecordNotFound
+	}
+	return
+}
+
+func (d *_default) GetCurrentRecruitmentWithClubUUID(ctx context.Context, clubUUID string) (recruit *model.ClubRecruitment, err error) {
 	recruit = new(model.ClubRecruitment)
 
-	fromSubQuery := d.tx.Table(model.ClubRecruitmentInstance.TableName()).Where("club_uuid = ?", clubUUID).Where("deleted_at IS NULL")
-	selectedTx := d.tx.Table("(?) as club_recruitments", fromSubQuery)
+	fromSubQuery := d.tx.WithContext(ctx).Table(model.ClubRecruitmentInstance.TableName()).Where("club_uuid = ?", clubUUID).Where("deleted_at IS NULL")
+	selectedTx := d.tx.WithContext(ctx).Table("(?) as club_recruitments", fromSubQuery)
 	selectResult := selectedTx.Where("club_recruitments.end_period >= ?", time.Now().AddDate(0, 0, -1)).Or("club_recruitments.end_period IS NULL").Find(recruit)
 
 	err = selectResult.Error
@@ -41,11 +59,11 @@ func (d *_default) GetCurrentRecruitmentWithClubUUID(clubUUID string) (recruit *
 	return
 }
 
-func (d *_default) GetCurrentRecruitmentWithRecruitmentUUID(recruitmentUUID string) (recruit *model.ClubRecruitment, err error) {
+func (d *_default) GetCurrentRecruitmentWithRecruitmentUUID(ctx context.Context, recruitmentUUID string) (recruit *model.ClubRecruitment, err error) {
 	recruit = new(model.ClubRecruitment)
 
-	fromSubQuery := d.tx.Table(model.ClubRecruitmentInstance.TableName()).Where("uuid = ?", recruitmentUUID).Where("deleted_at IS NULL")
-	selectedTx := d.tx.Table("(?) as club_recruitments", fromSubQuery)
+	fromSubQuery := d.tx.WithContext(ctx).Table(model.ClubRecruitmentInstance.TableName()).Where("uuid = ?", recruitmentUUID).Where("deleted_at IS NULL")
+	selectedTx := d.tx.WithContext(ctx).Table("(?) as club_recruitments", fromSubQuery)
 	selectResult := selectedTx.Where("club_recruitments.end_period >= ?", time.Now().AddDate(0, 0, -1)).Or("club_recruitments.end_period IS NULL").Find(recruit)
 
 	err = selectResult.Error
@@ -56,8 +74,8 @@ func (d *_default) GetCurrentRecruitmentWithRecruitmentUUID(recruitmentUUID stri
 	return
 }
 
-func (d *_default) GetClubInformsSortByUpdateTime(offset, limit int, field, name string) (clubInforms []*model.ClubInform, err error) {
-	selectedTx := d.tx.Table(model.ClubInformInstance.TableName())
+func (d *_default) GetClubInformsSortByUpdateTime(ctx context.Context, offset, limit int, field, name string) (clubInforms []*model.ClubInform, err error) {
+	selectedTx := d.tx.WithContext(ctx).Table(model.ClubInformInstance.TableName())
 	if field != "" {
 		selectedTx = selectedTx.Where("field LIKE ?", "%"+field+"%")
 	}
@@ -75,8 +93,8 @@ func (d *_default) GetClubInformsSortByUpdateTime(offset, limit int, field, name
 	return
 }
 
-func (d *_default) GetCurrentRecruitmentsSortByCreateTime(offset, limit int, field, name string) (recruits []*model.ClubRecruitment, err error) {
-	fromSubQuery := d.tx.Table(model.ClubRecruitmentInstance.TableName()).Select("club_recruitments.*").Where("club_recruitments.deleted_at IS NULL")
+func (d *_default) GetCurrentRecruitmentsSortByCreateTime(ctx context.Context, offset, limit int, field, name string) (recruits []*model.ClubRecruitment, err error) {
+	fromSubQuery := d.tx.WithContext(ctx).Table(model.ClubRecruitmentInstance.TableName()).Select("club_recruitments.*").Where("club_recruitments.deleted_at IS NULL")
 	fromSubQuery = fromSubQuery.Joins("JOIN club_informs ON club_informs.club_uuid = club_recruitments.club_uuid")
 	fromSubQuery = fromSubQuery.Where("club_informs.deleted_at IS NULL")
 
@@ -88,7 +106,7 @@ func (d *_default) GetCurrentRecruitmentsSortByCreateTime(offset, limit int, fie
 	}
 
 	recruits = make([]*model.ClubRecruitment, limit)
-	selectedTX := d.tx.Table("(?) as club_recruitments", fromSubQuery)
+	selectedTX := d.tx.WithContext(ctx).Table("(?) as club_recruitments", fromSubQuery)
 	selectedTX = selectedTX.Where("club_recruitments.end_period >= ?", time.Now().AddDate(0, 0, -1)).Or("club_recruitments.end_period IS NULL")
 	err = selectedTX.Order("club_recruitments.created_at desc").Limit(limit).Offset(offset).Find(&recruits).Error
 
@@ -99,9 +117,9 @@ func (d *_default) GetCurrentRecruitmentsSortByCreateTime(offset, limit int, fie
 	return
 }
 
-func (d *_default) GetClubInformWithClubUUID(clubUUID string) (inform *model.ClubInform, err error) {
+func (d *_default) GetClubInformWithClubUUID(ctx context.Context, clubUUID string) (inform *model.ClubInform, err error) {
 	inform = new(model.ClubInform)
-	selectResult := d.tx.Where("club_uuid = ?", clubUUID).Find(inform)
+	selectResult := d.tx.WithContext(ctx).Where("club_uuid = ?", clubUUID).Find(inform)
 	err = selectResult.Error
 	if selectResult.RowsAffected == 0 && err == nil {
 		err = gorm.ErrRecordNotFound
@@ -109,9 +127,9 @@ func (d *_default) GetClubInformWithClubUUID(clubUUID string) (inform *model.Clu
 	return
 }
 
-func (d *_default) GetRecruitmentWithRecruitmentUUID(recruitUUID string) (recruit *model.ClubRecruitment, err error) {
+func (d *_default) GetRecruitmentWithRecruitmentUUID(ctx context.Context, recruitUUID string) (recruit *model.ClubRecruitment, err error) {
 	recruit = new(model.ClubRecruitment)
-	selectResult := d.tx.Where("uuid = ?", recruitUUID).Find(recruit)
+	selectResult := d.tx.WithContext(ctx).Where("uuid = ?", recruitUUID).Find(recruit)
 	err = selectResult.Error
 	if selectResult.RowsAffected == 0 && err == nil {
 		err = gorm.ErrRecordNotFound
@@ -120,9 +138,9 @@ func (d *_default) GetRecruitmentWithRecruitmentUUID(recruitUUID string) (recrui
 	return
 }
 
-func (d *_default) GetClubMembersWithClubUUID(clubUUID string) ([]*model.ClubMember, error) {
+func (d *_default) GetClubMembersWithClubUUID(ctx context.Context, clubUUID string) ([]*model.ClubMember, error) {
 	var members []*model.ClubMember
-	err := d.tx.Where("club_uuid = ?", clubUUID).Find(&members).Error
+	err := d.tx.WithContext(ctx).Where("club_uuid = ?", clubUUID).Find(&members).Error
 
 	if len(members) == 0 && err == nil {
 		err = gorm.ErrRecordNotFound
@@ -131,9 +149,9 @@ func (d *_default) GetClubMembersWithClubUUID(clubUUID string) ([]*model.ClubMem
 	return members, err
 }
 
-func (d *_default) GetRecruitMembersWithRecruitmentUUID(recruitUUID string) ([]*model.RecruitMember, error) {
+func (d *_default) GetRecruitMembersWithRecruitmentUUID(ctx context.Context, recruitUUID string) ([]*model.RecruitMember, error) {
 	var members []*model.RecruitMember
-	err := d.tx.Where("recruitment_uuid = ?", recruitUUID).Find(&members).Error
+	err := d.tx.WithContext(ctx).Where("recruitment_uuid = ?", recruitUUID).Find(&members).Error
 
 	if len(members) == 0 && err == nil {
 		err = gorm.ErrRecordNotFound
@@ -142,8 +160,8 @@ func (d *_default) GetRecruitMembersWithRecruitmentUUID(recruitUUID string) ([]*
 	return members, err
 }
 
-func (d *_default) GetAllClubInforms() ([]*model.ClubInform, error) {
-	joinedTx := d.tx.Table(model.ClubInformInstance.TableName()).Joins("JOIN clubs ON clubs.uuid = club_informs.club_uuid")
+func (d *_default) GetAllClubInforms(ctx context.Context) ([]*model.ClubInform, error) {
+	joinedTx := d.tx.WithContext(ctx).Table(model.ClubInformInstance.TableName()).Joins("JOIN clubs ON clubs.uuid = club_informs.club_uuid")
 	joinedTx = joinedTx.Where("clubs.deleted_at IS NULL")
 
 	var informs []*model.ClubInform
@@ -156,12 +174,12 @@ func (d *_default) GetAllClubInforms() ([]*model.ClubInform, error) {
 	return informs, err
 }
 
-func (d *_default) GetAllCurrentRecruitments() ([]*model.ClubRecruitment, error) {
-	fromSubQuery := d.tx.Table(model.ClubRecruitmentInstance.TableName()).Select("club_recruitments.*").Where("club_recruitments.deleted_at IS NULL")
+func (d *_default) GetAllCurrentRecruitments(ctx context.Context) ([]*model.ClubRecruitment, error) {
+	fromSubQuery := d.tx.WithContext(ctx).Table(model.ClubRecruitmentInstance.TableName()).Select("club_recruitments.*").Where("club_recruitments.deleted_at IS NULL")
 	fromSubQuery = fromSubQuery.Joins("JOIN clubs ON clubs.uuid = club_recruitments.club_uuid").Where("clubs.deleted_at IS NULL")
 
 	var recruitments []*model.ClubRecruitment
-	selectedTx := d.tx.Table("(?) AS club_recruitments", fromSubQuery)
+	selectedTx := d.tx.WithContext(ctx).Table("(?) AS club_recruitments", fromSubQuery)
 	err := selectedTx.Where("club_recruitments.end_period >= ?", time.Now().AddDate(0, 0, -1)).Or("club_recruitments.end_period IS NULL").Find(&recruitments).Error
 
 	if len(recruitments) == 0 && err == nil {
@@ -171,8 +189,8 @@ func (d *_default) GetAllCurrentRecruitments() ([]*model.ClubRecruitment, error)
 	return recruitments, err
 }
 
-func (d *_default) GetClubInformsWithFloor(floor string) ([]*model.ClubInform, error) {
-	joinedTx := d.tx.Table(model.ClubInformInstance.TableName()).Joins("JOIN clubs ON clubs.uuid = club_informs.club_uuid")
+func (d *_default) GetClubInformsWithFloor(ctx context.Context, floor string) ([]*model.ClubInform, error) {
+	joinedTx := d.tx.WithContext(ctx).Table(model.ClubInformInstance.TableName()).Joins("JOIN clubs ON clubs.uuid = club_informs.club_uuid")
 	joinedTx = joinedTx.Where("clubs.deleted_at IS NULL")
 
 	var informs []*model.ClubInform
@@ -184,3 +202,119 @@ func (d *_default) GetClubInformsWithFloor(floor string) ([]*model.ClubInform, e
 
 	return informs, err
 }
+
+// select clubs with uuid list in one query with IN condition, order of result is not same with uuid list
+func (d *_default) GetClubsWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.Club, error) {
+	var clubs []*model.Club
+	var err error
+	if len(clubUUIDs) != 0 {
+		err = d.tx.WithContext(ctx).Where("uuid IN ?", clubUUIDs).Find(&clubs).Error
+	}
+
+	if len(clubs) == 0 && err == nil {
+		err = gorm.ErrRecordNotFound
+	}
+
+	return clubs, err
+}
+
+func (d *_default) GetClubInformsWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.ClubInform, error) {
+	var informs []*model.ClubInform
+	var err error
+	if len(clubUUIDs) != 0 {
+		err = d.tx.WithContext(ctx).Where("club_uuid IN ?", clubUUIDs).Find(&informs).Error
+	}
+
+	if len(informs) == 0 && err == nil {
+		err = gorm.ErrRecordNotFound
+	}
+
+	return informs, err
+}
+
+func (d *_default) GetClubMembersWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.ClubMember, error) {
+	var members []*model.ClubMember
+	var err error
+	if len(clubUUIDs) != 0 {
+		err = d.tx.WithContext(ctx).Where("club_uuid IN ?", clubUUIDs).Find(&members).Error
+	}
+
+	if len(members) == 0 && err == nil {
+		err = gorm.ErrRecordNotFound
+	}
+
+	return members, err
+}
+
+func (d *_default) GetRecruitMembersWithRecruitmentUUIDs(ctx context.Context, recruitUUIDs []string) ([]*model.RecruitMember, error) {
+	var members []*model.RecruitMember
+	var err error
+	if len(recruitUUIDs) != 0 {
+		err = d.tx.WithContext(ctx).Where("recruitment_uuid IN ?", recruitUUIDs).Find(&members).Error
+	}
+
+	if len(members) == 0 && err == nil {
+		err = gorm.ErrRecordNotFound
+	}
+
+	return members, err
+}
+
+// count informs of not deleted club in DB, filter is same with GetClubInformsSortByUpdateTime & empty filter is ignored
+func (d *_default) GetClubInformsCount(ctx context.Context, field, name, floor string) (count int64, err error) {
+	joinedTx := d.tx.WithContext(ctx).Model(&model.ClubInform{}).Joins("JOIN clubs ON clubs.uuid = club_informs.club_uuid")
+	joinedTx = joinedTx.Where("clubs.deleted_at IS NULL")
+
+	if field != "" {
+		joinedTx = joinedTx.Where("club_informs.field LIKE ?", "%"+field+"%")
+	}
+	if name != "" {
+		joinedTx = joinedTx.Where("club_informs.name LIKE ?", "%"+name+"%")
+	}
+	if floor != "" {
+		joinedTx = joinedTx.Where("club_informs.floor = ?", floor)
+	}
+
+	err = joinedTx.Count(&count).Error
+	return
+}
+
+// count current recruitments of not deleted club in DB, filter is same with GetCurrentRecruitmentsSortByCreateTime
+func (d *_default) GetCurrentRecruitmentsCount(ctx context.Context, field, name string) (count int64, err error) {
+	fromSubQuery := d.tx.WithContext(ctx).Table(model.ClubRecruitmentInstance.TableName()).Select("club_recruitments.*").Where("club_recruitments.deleted_at IS NULL")
+	fromSubQuery = fromSubQuery.Joins("JOIN clubs ON clubs.uuid = club_recruitments.club_uuid").Where("clubs.deleted_at IS NULL")
+	fromSubQuery = fromSubQuery.Joins("JOIN club_informs ON club_informs.club_uuid = club_recruitments.club_uuid")
+	fromSubQuery = fromSubQuery.Where("club_informs.deleted_at IS NULL")
+
+	if field != "" {
+		fromSubQuery = fromSubQuery.Where("club_informs.field LIKE ?", "%"+field+"%")
+	}
+	if name != "" {
+		fromSubQuery = fromSubQuery.Where("club_informs.name LIKE ?", "%"+name+"%")
+	}
+
+	selectedTx := d.tx.WithContext(ctx).Table("(?) AS club_recruitments", fromSubQuery)
+	err = selectedTx.Where("club_recruitments.end_period >= ?", time.Now().AddDate(0, 0, -1)).Or("club_recruitments.end_period IS NULL").Count(&count).Error
+	return
+}
+
+// select distinct fields of not deleted club in DB, empty filter is ignored
+func (d *_default) GetDistinctClubFields(ctx context.Context, name, floor string) (fields []string, err error) {
+	joinedTx := d.tx.WithContext(ctx).Model(&model.ClubInform{}).Joins("JOIN clubs ON clubs.uuid = club_informs.club_uuid")
+	joinedTx = joinedTx.Where("clubs.deleted_at IS NULL")
+
+	if name != "" {
+		joinedTx = joinedTx.Where("club_informs.name LIKE ?", "%"+name+"%")
+	}
+	if floor != "" {
+		joinedTx = joinedTx.Where("club_informs.floor = ?", floor)
+	}
+
+	err = joinedTx.Distinct().Pluck("club_informs.field", &fields).Error
+
+	if len(fields) == 0 && err == nil {
+		err = gorm.ErrRecordNotFound
+	}
+
+	return
+}
@@ -3,11 +3,17 @@ package access
 import (
 	"club/db/access/errors"
 	"club/model"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
 	"time"
 )
 
-func (d *_default) ChangeClubLeader(clubUUID, newLeaderUUID string) (err error, rowAffected int64) {
-	updateResult := d.tx.Model(&model.Club{}).Where("uuid = ?", clubUUID).Updates(&model.Club{
+// version of row is not checked if version read by editor is unknown (ex. request without version), but still increased
+const UncheckedVersion = 0
+
+func (d *_default) ChangeClubLeader(ctx context.Context, clubUUID, newLeaderUUID string) (err error, rowAffected int64) {
+	updateResult := d.tx.WithContext(ctx).Model(&model.Club{}).Where("uuid = ?", clubUUID).Updates(&model.Club{
 		LeaderUUID: model.LeaderUUID(newLeaderUUID),
 	})
 	err = updateResult.Error
@@ -15,19 +21,36 @@ func (d *_default) ChangeClubLeader(clubUUID, newLeaderUUID string) (err error,
 	return
 }
 
-func (d *_default) ModifyClubInform(clubUUID string, revisionInform *model.ClubInform) (err error, rowAffected int64) {
+// update only if version of row is same with version read by editor, and version is increased with update
+func (d *_default) ModifyClubInform(ctx context.Context, clubUUID string, version uint, revisionInform *model.ClubInform) (err error, rowAffected int64) {
 	if revisionInform.ClubUUID != "" {
 		err = errors.ClubUUIDCannotBeChanged
 		return
 	}
 
-	updateResult := d.tx.Model(&model.ClubInform{}).Where("club_uuid = ?", clubUUID).Updates(revisionInform)
+	if version == UncheckedVersion {
+		updateResult := d.tx.WithContext(ctx).Model(&model.ClubInform{}).Where("club_uuid = ?", clubUUID).Updates(revisionInform)
+		err = updateResult.Error
+		rowAffected = updateResult.RowsAffected
+		if err == nil && rowAffected != 0 {
+			err = d.increaseVersion(ctx, &model.ClubInform{}, model.ClubInformInstance.Version.KeyName(), "club_uuid = ?", clubUUID)
+		}
+		return
+	}
+
+	revisionInform.Version = model.Version(version + 1)
+	updateResult := d.tx.WithContext(ctx).Model(&model.ClubInform{}).Where("club_uuid = ? AND version = ?", clubUUID, version).Updates(revisionInform)
 	err = updateResult.Error
 	rowAffected = updateResult.RowsAffected
+
+	if err == nil && rowAffected == 0 {
+		err = d.versionConflictErrorIfExist(ctx, &model.ClubInform{}, "club_uuid = ?", clubUUID)
+	}
 	return
 }
 
-func (d *_default) ModifyRecruitment(recruitUUID string, revisionRecruit *model.ClubRecruitment) (err error, rowAffected int64) {
+// update only if version of row is same with version read by editor, and version is increased with update
+func (d *_default) ModifyRecruitment(ctx context.Context, recruitUUID string, version uint, revisionRecruit *model.ClubRecruitment) (err error, rowAffected int64) {
 	if revisionRecruit.UUID != "" {
 		err = errors.RecruitmentUUIDCannotBeChanged
 		return
@@ -39,6 +62,9 @@ func (d *_default) ModifyRecruitment(recruitUUID string, revisionRecruit *model.
 	}
 
 	var updateAttrs = []interface{}{"updated_at"}
+	if version != UncheckedVersion {
+		updateAttrs = append(updateAttrs, model.ClubRecruitmentInstance.Version.KeyName())
+	}
 
 	if revisionRecruit.RecruitConcept != "" {
 		updateAttrs = append(updateAttrs, model.ClubRecruitmentInstance.RecruitConcept.KeyName())
@@ -59,9 +85,43 @@ func (d *_default) ModifyRecruitment(recruitUUID string, revisionRecruit *model.
 	}
 
 	revisionRecruit.UpdatedAt = time.Now()
-	selectedTx := d.tx.Model(&model.ClubRecruitment{}).Select(updateAttrs[0], updateAttrs[1:]...)
-	updateResult := selectedTx.Where("uuid = ?", recruitUUID).Updates(revisionRecruit)
+	if version == UncheckedVersion {
+		selectedTx := d.tx.WithContext(ctx).Model(&model.ClubRecruitment{}).Select(updateAttrs[0], updateAttrs[1:]...)
+		updateResult := selectedTx.Where("uuid = ?", recruitUUID).Updates(revisionRecruit)
+		err = updateResult.Error
+		rowAffected = updateResult.RowsAffected
+		if err == nil && rowAffected != 0 {
+			err = d.increaseVersion(ctx, &model.ClubRecruitment{}, model.ClubRecruitmentInstance.Version.KeyName(), "uuid = ?", recruitUUID)
+		}
+		return
+	}
+
+	revisionRecruit.Version = model.Version(version + 1)
+	selectedTx := d.tx.WithContext(ctx).Model(&model.ClubRecruitment{}).Select(updateAttrs[0], updateAttrs[1:]...)
+	updateResult := selectedTx.Where("uuid = ? AND version = ?", recruitUUID, version).Updates(revisionRecruit)
 	err = updateResult.Error
 	rowAffected = updateResult.RowsAffected
+
+	if err == nil && rowAffected == 0 {
+		err = d.versionConflictErrorIfExist(ctx, &model.ClubRecruitment{}, "uuid = ?", recruitUUID)
+	}
+	return
+}
+
+// increase version of rows updated without version check, so that editor who read previous version gets conflict
+func (d *_default) increaseVersion(ctx context.Context, table interface{}, column string, query string, args ...interface{}) (err error) {
+	err = d.tx.WithContext(ctx).Model(table).Where(query, args...).UpdateColumn(column, gorm.Expr(fmt.Sprintf("%s + ?", column), 1)).Error
+	return
+}
+
+// returns VersionConflict error if row is exist, used to distinguish version conflict from not exist row when 0 row is updated
+func (d *_default) versionConflictErrorIfExist(ctx context.Context, table interface{}, query string, args ...interface{}) (err error) {
+	var count int64
+	if err = d.tx.WithContext(ctx).Model(table).Where(query, args...).Count(&count).Error; err != nil {
+		return
+	}
+	if count != 0 {
+		err = errors.VersionConflict
+	}
 	return
 }
@@ -5,4 +5,5 @@ import "errors"
 var (
 	RecruitmentUUIDCannotBeChanged = errors.New("recruitment uuid cannot be changed")
 	ClubUUIDCannotBeChanged = errors.New("club uuid cannot be changed")
+	VersionConflict = errors.New("row is already modified by other request, version is not matched")
 )
\ No newline at end of file
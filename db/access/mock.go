@@ -2,10 +2,12 @@ package access
 
 import (
 	"club/model"
+	"context"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
+// ctx is not passed to mock.Called, so that expected arguments of method don't depend on context of request
 type _mock struct {
 	mock *mock.Mock
 }
@@ -14,137 +16,177 @@ func Mock(mock *mock.Mock) _mock {
 	return _mock{mock: mock}
 }
 
-func (m _mock) CreateClub(club *model.Club) (resultClub *model.Club, err error) {
+func (m _mock) CreateClub(ctx context.Context, club *model.Club) (resultClub *model.Club, err error) {
 	args := m.mock.Called(club)
 	return args.Get(0).(*model.Club), args.Error(1)
 }
 
-func (m _mock) CreateClubInform(inform *model.ClubInform) (resultInform *model.ClubInform, err error) {
+func (m _mock) CreateClubInform(ctx context.Context, inform *model.ClubInform) (resultInform *model.ClubInform, err error) {
 	args := m.mock.Called(inform)
 	return args.Get(0).(*model.ClubInform), args.Error(1)
 }
 
-func (m _mock) CreateClubMember(clubMember *model.ClubMember) (resultMember *model.ClubMember, err error) {
+func (m _mock) CreateClubMember(ctx context.Context, clubMember *model.ClubMember) (resultMember *model.ClubMember, err error) {
 	args := m.mock.Called(clubMember)
 	return args.Get(0).(*model.ClubMember), args.Error(1)
 }
 
-func (m _mock) CreateRecruitment(recruit *model.ClubRecruitment) (resultRecruit *model.ClubRecruitment, err error) {
+func (m _mock) CreateRecruitment(ctx context.Context, recruit *model.ClubRecruitment) (resultRecruit *model.ClubRecruitment, err error) {
 	args := m.mock.Called(recruit)
 	return args.Get(0).(*model.ClubRecruitment), args.Error(1)
 }
 
-func (m _mock) CreateRecruitMember(recruitMember *model.RecruitMember) (resultMember *model.RecruitMember, err error) {
+func (m _mock) CreateRecruitMember(ctx context.Context, recruitMember *model.RecruitMember) (resultMember *model.RecruitMember, err error) {
 	args := m.mock.Called(recruitMember)
 	return args.Get(0).(*model.RecruitMember), args.Error(1)
 }
 
-func (m _mock) GetClubWithClubUUID(clubUUID string) (*model.Club, error) {
+func (m _mock) GetClubWithClubUUID(ctx context.Context, clubUUID string) (*model.Club, error) {
 	args := m.mock.Called(clubUUID)
 	return args.Get(0).(*model.Club), args.Error(1)
 }
 
-func (m _mock) GetClubWithLeaderUUID(leaderUUID string) (*model.Club, error) {
+func (m _mock) GetClubWithClubUUIDForUpdate(ctx context.Context, clubUUID string) (*model.Club, error) {
+	args := m.mock.Called(clubUUID)
+	return args.Get(0).(*model.Club), args.Error(1)
+}
+
+func (m _mock) GetClubWithLeaderUUID(ctx context.Context, leaderUUID string) (*model.Club, error) {
 	args := m.mock.Called(leaderUUID)
 	return args.Get(0).(*model.Club), args.Error(1)
 }
 
-func (m _mock) GetCurrentRecruitmentWithClubUUID(clubUUID string) (*model.ClubRecruitment, error) {
+func (m _mock) GetCurrentRecruitmentWithClubUUID(ctx context.Context, clubUUID string) (*model.ClubRecruitment, error) {
 	args := m.mock.Called(clubUUID)
 	return args.Get(0).(*model.ClubRecruitment), args.Error(1)
 }
 
-func (m _mock) GetCurrentRecruitmentWithRecruitmentUUID(recruitmentUUID string) (*model.ClubRecruitment, error) {
+func (m _mock) GetCurrentRecruitmentWithRecruitmentUUID(ctx context.Context, recruitmentUUID string) (*model.ClubRecruitment, error) {
 	args := m.mock.Called(recruitmentUUID)
 	return args.Get(0).(*model.ClubRecruitment), args.Error(1)
 }
 
-func (m _mock) GetClubInformsSortByUpdateTime(offset, limit int, field, name string) ([]*model.ClubInform, error) {
+func (m _mock) GetClubInformsSortByUpdateTime(ctx context.Context, offset, limit int, field, name string) ([]*model.ClubInform, error) {
 	args := m.mock.Called(offset, limit, field, name)
 	return args.Get(0).([]*model.ClubInform), args.Error(1)
 }
 
-func (m _mock) GetCurrentRecruitmentsSortByCreateTime(offset, limit int, field, name string) ([]*model.ClubRecruitment, error) {
+func (m _mock) GetCurrentRecruitmentsSortByCreateTime(ctx context.Context, offset, limit int, field, name string) ([]*model.ClubRecruitment, error) {
 	args := m.mock.Called(offset, limit, field, name)
 	return args.Get(0).([]*model.ClubRecruitment), args.Error(1)
 }
 
-func (m _mock) GetClubInformWithClubUUID(clubUUID string) (*model.ClubInform, error) {
+func (m _mock) GetClubInformWithClubUUID(ctx context.Context, clubUUID string) (*model.ClubInform, error) {
 	args := m.mock.Called(clubUUID)
 	return args.Get(0).(*model.ClubInform), args.Error(1)
 }
 
-func (m _mock) GetRecruitmentWithRecruitmentUUID(recruitUUID string) (*model.ClubRecruitment, error) {
+func (m _mock) GetRecruitmentWithRecruitmentUUID(ctx context.Context, recruitUUID string) (*model.ClubRecruitment, error) {
 	args := m.mock.Called(recruitUUID)
 	return args.Get(0).(*model.ClubRecruitment), args.Error(1)
 }
 
-func (m _mock) GetClubMembersWithClubUUID(clubUUID string) ([]*model.ClubMember, error) {
+func (m _mock) GetClubMembersWithClubUUID(ctx context.Context, clubUUID string) ([]*model.ClubMember, error) {
 	args := m.mock.Called(clubUUID)
 	return args.Get(0).([]*model.ClubMember), args.Error(1)
 }
 
-func (m _mock) GetRecruitMembersWithRecruitmentUUID(recruitUUID string) ([]*model.RecruitMember, error) {
+func (m _mock) GetRecruitMembersWithRecruitmentUUID(ctx context.Context, recruitUUID string) ([]*model.RecruitMember, error) {
 	args := m.mock.Called(recruitUUID)
 	return args.Get(0).([]*model.RecruitMember), args.Error(1)
 }
 
-func (m _mock) GetAllClubInforms() ([]*model.ClubInform, error) {
+func (m _mock) GetAllClubInforms(ctx context.Context) ([]*model.ClubInform, error) {
 	args := m.mock.Called()
 	return args.Get(0).([]*model.ClubInform), args.Error(1)
 }
 
-func (m _mock) GetAllCurrentRecruitments() ([]*model.ClubRecruitment, error) {
+func (m _mock) GetAllCurrentRecruitments(ctx context.Context) ([]*model.ClubRecruitment, error) {
 	args := m.mock.Called()
 	return args.Get(0).([]*model.ClubRecruitment), args.Error(1)
 }
 
-func (m _mock) GetClubInformsWithFloor(floor string) ([]*model.ClubInform, error) {
+func (m _mock) GetClubInformsWithFloor(ctx context.Context, floor string) ([]*model.ClubInform, error) {
 	args := m.mock.Called()
 	return args.Get(0).([]*model.ClubInform), args.Error(1)
 }
 
-func (m _mock) ChangeClubLeader(clubUUID, newLeaderUUID string) (error, int64) {
+func (m _mock) GetClubsWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.Club, error) {
+	args := m.mock.Called(clubUUIDs)
+	return args.Get(0).([]*model.Club), args.Error(1)
+}
+
+func (m _mock) GetClubInformsWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.ClubInform, error) {
+	args := m.mock.Called(clubUUIDs)
+	return args.Get(0).([]*model.ClubInform), args.Error(1)
+}
+
+func (m _mock) GetClubMembersWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.ClubMember, error) {
+	args := m.mock.Called(clubUUIDs)
+	return args.Get(0).([]*model.ClubMember), args.Error(1)
+}
+
+func (m _mock) GetRecruitMembersWithRecruitmentUUIDs(ctx context.Context, recruitUUIDs []string) ([]*model.RecruitMember, error) {
+	args := m.mock.Called(recruitUUIDs)
+	return args.Get(0).([]*model.RecruitMember), args.Error(1)
+}
+
+func (m _mock) GetClubInformsCount(ctx context.Context, field, name, floor string) (int64, error) {
+	args := m.mock.Called(field, name, floor)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m _mock) GetCurrentRecruitmentsCount(ctx context.Context, field, name string) (int64, error) {
+	args := m.mock.Called(field, name)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m _mock) GetDistinctClubFields(ctx context.Context, name, floor string) ([]string, error) {
+	args := m.mock.Called(name, floor)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m _mock) ChangeClubLeader(ctx context.Context, clubUUID, newLeaderUUID string) (error, int64) {
 	args := m.mock.Called(clubUUID, newLeaderUUID)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) ModifyClubInform(clubUUID string, revisionInform *model.ClubInform) (error, int64) {
-	args := m.mock.Called(clubUUID, revisionInform)
+func (m _mock) ModifyClubInform(ctx context.Context, clubUUID string, version uint, revisionInform *model.ClubInform) (error, int64) {
+	args := m.mock.Called(clubUUID, version, revisionInform)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) ModifyRecruitment(recruitUUID string, revisionRecruit *model.ClubRecruitment) (error, int64) {
-	args := m.mock.Called(recruitUUID, revisionRecruit)
+func (m _mock) ModifyRecruitment(ctx context.Context, recruitUUID string, version uint, revisionRecruit *model.ClubRecruitment) (error, int64) {
+	args := m.mock.Called(recruitUUID, version, revisionRecruit)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) DeleteClub(clubUUID string) (error, int64) {
+func (m _mock) DeleteClub(ctx context.Context, clubUUID string) (error, int64) {
 	args := m.mock.Called(clubUUID)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) DeleteClubInform(clubUUID string) (error, int64) {
+func (m _mock) DeleteClubInform(ctx context.Context, clubUUID string) (error, int64) {
 	args := m.mock.Called(clubUUID)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) DeleteClubMember(clubUUID, studentUUID string) (error, int64) {
+func (m _mock) DeleteClubMember(ctx context.Context, clubUUID, studentUUID string) (error, int64) {
 	args := m.mock.Called(clubUUID, studentUUID)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) DeleteAllClubMembers(clubUUID string) (error, int64) {
+func (m _mock) DeleteAllClubMembers(ctx context.Context, clubUUID string) (error, int64) {
 	args := m.mock.Called(clubUUID)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) DeleteRecruitment(recruitUUID string) (error, int64) {
+func (m _mock) DeleteRecruitment(ctx context.Context, recruitUUID string) (error, int64) {
 	args := m.mock.Called(recruitUUID)
 	return args.Error(0), int64(args.Int(1))
 }
 
-func (m _mock) DeleteAllRecruitMember(recruitUUID string) (error, int64) {
+func (m _mock) DeleteAllRecruitMember(ctx context.Context, recruitUUID string) (error, int64) {
 	args := m.mock.Called(recruitUUID)
 	return args.Error(0), int64(args.Int(1))
 }
@@ -2,42 +2,50 @@ package access
 
 import (
 	"club/model"
+	"context"
 	"gorm.io/gorm"
-
 )
 
 type None struct {}
 
-func (n None) CreateClub(club *model.Club) (_ *model.Club, _ error) { return }
-func (n None) CreateClubInform(inform *model.ClubInform) (_ *model.ClubInform, _ error) { return }
-func (n None) CreateClubMember(clubMember *model.ClubMember) (_ *model.ClubMember, _ error) { return }
-func (n None) CreateRecruitment(recruit *model.ClubRecruitment) (_ *model.ClubRecruitment, _ error) { return }
-func (n None) CreateRecruitMember(recruitMember *model.RecruitMember) (_ *model.RecruitMember, _ error) { return }
-
-func (n None) GetClubWithClubUUID(clubUUID string) (_ *model.Club, _ error) { return }
-func (n None) GetClubWithLeaderUUID(leaderUUID string) (_ *model.Club, _ error) { return }
-func (n None) GetCurrentRecruitmentWithClubUUID(clubUUID string) (_ *model.ClubRecruitment, _ error) { return }
-func (n None) GetCurrentRecruitmentWithRecruitmentUUID(recruitmentUUID string) (_ *model.ClubRecruitment, _ error) { return }
-func (n None) GetClubInformsSortByUpdateTime(offset, limit int, field, name string) (_ []*model.ClubInform, _ error) { return }
-func (n None) GetCurrentRecruitmentsSortByCreateTime(offset, limit int, field, name string) (_ []*model.ClubRecruitment, _ error) { return }
-func (n None) GetClubInformWithClubUUID(clubUUID string) (_ *model.ClubInform, _ error) { return }
-func (n None) GetRecruitmentWithRecruitmentUUID(recruitUUID string) (_ *model.ClubRecruitment, _ error) { return }
-func (n None) GetClubMembersWithClubUUID(clubUUID string) (_ []*model.ClubMember, _ error) { return }
-func (n None) GetRecruitMembersWithRecruitmentUUID(recruitUUID string) (_ []*model.RecruitMember, _ error) { return }
-func (n None) GetAllClubInforms() (_ []*model.ClubInform, _ error) { return }
-func (n None) GetAllCurrentRecruitments() (_ []*model.ClubRecruitment, _ error) { return }
-func (n None) GetClubInformsWithFloor(floor string) (_ []*model.ClubInform, _ error) { return }
-
-func (n None) ChangeClubLeader(clubUUID, newLeaderUUID string) (_ error, _ int64) { return }
-func (n None) ModifyClubInform(clubUUID string, revisionInform *model.ClubInform) (_ error, _ int64) { return }
-func (n None) ModifyRecruitment(recruitUUID string, revisionRecruit *model.ClubRecruitment) (_ error, _ int64) { return }
-
-func (n None) DeleteClub(clubUUID string) (_ error, _ int64) { return }
-func (n None) DeleteClubInform(clubUUID string) (_ error, _ int64) { return }
-func (n None) DeleteClubMember(clubUUID, studentUUID string) (_ error, _ int64) { return }
-func (n None) DeleteAllClubMembers(clubUUID string) (err error, rowsAffected int64) { return }
-func (n None) DeleteRecruitment(recruitUUID string) (_ error, _ int64) { return }
-func (n None) DeleteAllRecruitMember(recruitUUID string) (_ error, _ int64) { return }
+func (n None) CreateClub(ctx context.Context, club *model.Club) (_ *model.Club, _ error) { return }
+func (n None) CreateClubInform(ctx context.Context, inform *model.ClubInform) (_ *model.ClubInform, _ error) { return }
+func (n None) CreateClubMember(ctx context.Context, clubMember *model.ClubMember) (_ *model.ClubMember, _ error) { return }
+func (n None) CreateRecruitment(ctx context.Context, recruit *model.ClubRecruitment) (_ *model.ClubRecruitment, _ error) { return }
+func (n None) CreateRecruitMember(ctx context.Context, recruitMember *model.RecruitMember) (_ *model.RecruitMember, _ error) { return }
+
+func (n None) GetClubWithClubUUID(ctx context.Context, clubUUID string) (_ *model.Club, _ error) { return }
+func (n None) GetClubWithClubUUIDForUpdate(ctx context.Context, clubUUID string) (_ *model.Club, _ error) { return }
+func (n None) GetClubWithLeaderUUID(ctx context.Context, leaderUUID string) (_ *model.Club, _ error) { return }
+func (n None) GetCurrentRecruitmentWithClubUUID(ctx context.Context, clubUUID string) (_ *model.ClubRecruitment, _ error) { return }
+func (n None) GetCurrentRecruitmentWithRecruitmentUUID(ctx context.Context, recruitmentUUID string) (_ *model.ClubRecruitment, _ error) { return }
+func (n None) GetClubInformsSortByUpdateTime(ctx context.Context, offset, limit int, field, name string) (_ []*model.ClubInform, _ error) { return }
+func (n None) GetCurrentRecruitmentsSortByCreateTime(ctx context.Context, offset, limit int, field, name string) (_ []*model.ClubRecruitment, _ error) { return }
+func (n None) GetClubInformWithClubUUID(ctx context.Context, clubUUID string) (_ *model.ClubInform, _ error) { return }
+func (n None) GetRecruitmentWithRecruitmentUUID(ctx context.Context, recruitUUID string) (_ *model.ClubRecruitment, _ error) { return }
+func (n None) GetClubMembersWithClubUUID(ctx context.Context, clubUUID string) (_ []*model.ClubMember, _ error) { return }
+func (n None) GetRecruitMembersWithRecruitmentUUID(ctx context.Context, recruitUUID string) (_ []*model.RecruitMember, _ error) { return }
+func (n None) GetAllClubInforms(ctx context.Context) (_ []*model.ClubInform, _ error) { return }
+func (n None) GetAllCurrentRecruitments(ctx context.Context) (_ []*model.ClubRecruitment, _ error) { return }
+func (n None) GetClubInformsWithFloor(ctx context.Context, floor string) (_ []*model.ClubInform, _ error) { return }
+func (n None) GetClubsWithClubUUIDs(ctx context.Context, clubUUIDs []string) (_ []*model.Club, _ error) { return }
+func (n None) GetClubInformsWithClubUUIDs(ctx context.Context, clubUUIDs []string) (_ []*model.ClubInform, _ error) { return }
+func (n None) GetClubMembersWithClubUUIDs(ctx context.Context, clubUUIDs []string) (_ []*model.ClubMember, _ error) { return }
+func (n None) GetRecruitMembersWithRecruitmentUUIDs(ctx context.Context, recruitUUIDs []string) (_ []*model.RecruitMember, _ error) { return }
+func (n None) GetClubInformsCount(ctx context.Context, field, name, floor string) (_ int64, _ error) { return }
+func (n None) GetCurrentRecruitmentsCount(ctx context.Context, field, name string) (_ int64, _ error) { return }
+func (n None) GetDistinctClubFields(ctx context.Context, name, floor string) (_ []string, _ error) { return }
+
+func (n None) ChangeClubLeader(ctx context.Context, clubUUID, newLeaderUUID string) (_ error, _ int64) { return }
+func (n None) ModifyClubInform(ctx context.Context, clubUUID string, version uint, revisionInform *model.ClubInform) (_ error, _ int64) { return }
+func (n None) ModifyRecruitment(ctx context.Context, recruitUUID string, version uint, revisionRecruit *model.ClubRecruitment) (_ error, _ int64) { return }
+
+func (n None) DeleteClub(ctx context.Context, clubUUID string) (_ error, _ int64) { return }
+func (n None) DeleteClubInform(ctx context.Context, clubUUID string) (_ error, _ int64) { return }
+func (n None) DeleteClubMember(ctx context.Context, clubUUID, studentUUID string) (_ error, _ int64) { return }
+func (n None) DeleteAllClubMembers(ctx context.Context, clubUUID string) (err error, rowsAffected int64) { return }
+func (n None) DeleteRecruitment(ctx context.Context, recruitUUID string) (_ error, _ int64) { return }
+func (n None) DeleteAllRecruitMember(ctx context.Context, recruitUUID string) (_ error, _ int64) { return }
 
 func (n None) BeginTx() { return }
 func (n None) Commit() (_ *gorm.DB) { return }
@@ -2,40 +2,49 @@ package db
 
 import (
 	"club/model"
+	"context"
 	"gorm.io/gorm"
 )
 
 type Accessor interface {
-	CreateClub(club *model.Club) (resultClub *model.Club, err error)
-	CreateClubInform(inform *model.ClubInform) (resultInform *model.ClubInform, err error)
-	CreateClubMember(clubMember *model.ClubMember) (resultMember *model.ClubMember, err error)
-	CreateRecruitment(recruit *model.ClubRecruitment) (resultRecruit *model.ClubRecruitment, err error)
-	CreateRecruitMember(recruitMember *model.RecruitMember) (resultMember *model.RecruitMember, err error)
+	CreateClub(ctx context.Context, club *model.Club) (resultClub *model.Club, err error)
+	CreateClubInform(ctx context.Context, inform *model.ClubInform) (resultInform *model.ClubInform, err error)
+	CreateClubMember(ctx context.Context, clubMember *model.ClubMember) (resultMember *model.ClubMember, err error)
+	CreateRecruitment(ctx context.Context, recruit *model.ClubRecruitment) (resultRecruit *model.ClubRecruitment, err error)
+	CreateRecruitMember(ctx context.Context, recruitMember *model.RecruitMember) (resultMember *model.RecruitMember, err error)
 
-	GetClubWithClubUUID(clubUUID string) (*model.Club, error)
-	GetClubWithLeaderUUID(leaderUUID string) (*model.Club, error)
-	GetCurrentRecruitmentWithClubUUID(clubUUID string) (*model.ClubRecruitment, error)
-	GetCurrentRecruitmentWithRecruitmentUUID(recruitmentUUID string) (*model.ClubRecruitment, error)
-	GetClubInformsSortByUpdateTime(offset, limit int, field, name string) ([]*model.ClubInform, error)
-	GetCurrentRecruitmentsSortByCreateTime(offset, limit int, field, name string) ([]*model.ClubRecruitment, error)
-	GetClubInformWithClubUUID(clubUUID string) (*model.ClubInform, error)
-	GetRecruitmentWithRecruitmentUUID(recruitUUID string) (*model.ClubRecruitment, error)
-	GetClubMembersWithClubUUID(clubUUID string) ([]*model.ClubMember, error)
-	GetRecruitMembersWithRecruitmentUUID(recruitUUID string) ([]*model.RecruitMember, error)
-	GetAllClubInforms() ([]*model.ClubInform, error)
-	GetAllCurrentRecruitments() ([]*model.ClubRecruitment, error)
-	GetClubInformsWithFloor(floor string) ([]*model.ClubInform, error)
+	GetClubWithClubUUID(ctx context.Context, clubUUID string) (*model.Club, error)
+	GetClubWithClubUUIDForUpdate(ctx context.Context, clubUUID string) (*model.Club, error)
+	GetClubWithLeaderUUID(ctx context.Context, leaderUUID string) (*model.Club, error)
+	GetCurrentRecruitmentWithClubUUID(ctx context.Context, clubUUID string) (*model.ClubRecruitment, error)
+	GetCurrentRecruitmentWithRecruitmentUUID(ctx context.Context, recruitmentUUID string) (*model.ClubRecruitment, error)
+	GetClubInformsSortByUpdateTime(ctx context.Context, offset, limit int, field, name string) ([]*model.ClubInform, error)
+	GetCurrentRecruitmentsSortByCreateTime(ctx context.Context, offset, limit int, field, name string) ([]*model.ClubRecruitment, error)
+	GetClubInformWithClubUUID(ctx context.Context, clubUUID string) (*model.ClubInform, error)
+	GetRecruitmentWithRecruitmentUUID(ctx context.Context, recruitUUID string) (*model.ClubRecruitment, error)
+	GetClubMembersWithClubUUID(ctx context.Context, clubUUID string) ([]*model.ClubMember, error)
+	GetRecruitMembersWithRecruitmentUUID(ctx context.Context, recruitUUID string) ([]*model.RecruitMember, error)
+	GetAllClubInforms(ctx context.Context) ([]*model.ClubInform, error)
+	GetAllCurrentRecruitments(ctx context.Context) ([]*model.ClubRecruitment, error)
+	GetClubInformsWithFloor(ctx context.Context, floor string) ([]*model.ClubInform, error)
+	GetClubsWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.Club, error)
+	GetClubInformsWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.ClubInform, error)
+	GetClubMembersWithClubUUIDs(ctx context.Context, clubUUIDs []string) ([]*model.ClubMember, error)
+	GetRecruitMembersWithRecruitmentUUIDs(ctx context.Context, recruitUUIDs []string) ([]*model.RecruitMember, error)
+	GetClubInformsCount(ctx context.Context, field, name, floor string) (int64, error)
+	GetCurrentRecruitmentsCount(ctx context.Context, field, name string) (int64, error)
+	GetDistinctClubFields(ctx context.Context, name, floor string) ([]string, error)
 
-	ChangeClubLeader(clubUUID, newLeaderUUID string) (err error, rowsAffected int64)
-	ModifyClubInform(clubUUID string, revisionInform *model.ClubInform) (err error, rowsAffected int64)
-	ModifyRecruitment(recruitUUID string, revisionRecruit *model.ClubRecruitment) (err error, rowsAffected int64)
+	ChangeClubLeader(ctx context.Context, clubUUID, newLeaderUUID string) (err error, rowsAffected int64)
+	ModifyClubInform(ctx context.Context, clubUUID string, version uint, revisionInform *model.ClubInform) (err error, rowsAffected int64)
+	ModifyRecruitment(ctx context.Context, recruitUUID string, version uint, revisionRecruit *model.ClubRecruitment) (err error, rowsAffected int64)
 
-	DeleteClub(clubUUID string) (err error, rowsAffected int64)
-	DeleteClubInform(clubUUID string) (err error, rowsAffected int64)
-	DeleteClubMember(clubUUID, studentUUID string) (err error, rowsAffected int64)
-	DeleteAllClubMembers(clubUUID string) (err error, rowsAffected int64)
-	DeleteRecruitment(recruitUUID string) (err error, rowsAffected int64)
-	DeleteAllRecruitMember(recruitUUID string) (err error, rowsAffected int64)
+	DeleteClub(ctx context.Context, clubUUID string) (err error, rowsAffected int64)
+	DeleteClubInform(ctx context.Context, clubUUID string) (err error, rowsAffected int64)
+	DeleteClubMember(ctx context.Context, clubUUID, studentUUID string) (err error, rowsAffected int64)
+	DeleteAllClubMembers(ctx context.Context, clubUUID string) (err error, rowsAffected int64)
+	DeleteRecruitment(ctx context.Context, recruitUUID string) (err error, rowsAffected int64)
+	DeleteAllRecruitMember(ctx context.Context, recruitUUID string) (err error, rowsAffected int64)
 
 	BeginTx()
 	Commit() *gorm.DB
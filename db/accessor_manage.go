@@ -9,9 +9,11 @@ import (
 type AccessorManage struct {
 	accessorType  reflect.Type
 	accessorValue reflect.Value
+	replicas      *replicaSet
+	txRetry       txRetry
 }
 
-func NewAccessorManage(accessor Accessor) (manager AccessorManage, err error) {
+func NewAccessorManage(accessor Accessor, setters ...AccessorManageFieldSetter) (manager AccessorManage, err error) {
 	if accessor == nil {
 		err = errors.New(fmt.Sprintf("nil parameter is not allowed"))
 		return
@@ -28,6 +30,11 @@ func NewAccessorManage(accessor Accessor) (manager AccessorManage, err error) {
 	manager = AccessorManage{
 		accessorType:  accessorType,
 		accessorValue: accessorValue,
+		replicas:      &replicaSet{maxLag: defaultMaxReplicaLag, checkInterval: defaultReplicaCheckInterval, checkTimeout: defaultReplicaCheckTimeout},
+		txRetry:       txRetry{maxAttempts: defaultTxMaxAttempts, backoff: defaultTxRetryBackoff},
+	}
+	for _, setter := range setters {
+		setter(&manager)
 	}
 	return
 }
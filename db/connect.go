@@ -9,7 +9,6 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-	"os"
 	"strings"
 )
 
@@ -18,10 +17,19 @@ type ConnectionCfg struct {
 	Host    string `json:"host" validate:"required"`
 	Port 	int	   `json:"port" validate:"required"`
 	User    string `json:"user" validate:"required"`
+	// file path of DB in case of sqlite dialect
 	DB		string `json:"db" validate:"required"`
+	// read replicas of primary, connected with same user & db in ConnectReplicas
+	Replicas []ReplicaCfg `json:"replicas" validate:"dive"`
 }
 
-func ConnectWithConsul(cli *api.Client, key string) (db *gorm.DB, conf ConnectionCfg, err error) {
+type ReplicaCfg struct {
+	Host string `json:"host" validate:"required"`
+	Port int    `json:"port" validate:"required"`
+}
+
+// password is not saved in consul KV, so it is passed from configuration of service
+func ConnectWithConsul(cli *api.Client, key, password string) (db *gorm.DB, conf ConnectionCfg, err error) {
 	kv, _, err := cli.KV().Get(key, nil)
 	if err != nil {
 		err = errors.New(fmt.Sprintf("unable to get %s KV from consul, err: %v", key, err.Error()))
@@ -33,27 +41,67 @@ func ConnectWithConsul(cli *api.Client, key string) (db *gorm.DB, conf Connectio
 		return
 	}
 
-	if err = validator.New().Struct(&conf); err != nil {
+	conf.Dialect = strings.ToLower(conf.Dialect)
+	if conf.Dialect == "sqlite" {
+		// sqlite DB is file, so there is no host, port & user
+		err = validator.New().StructExcept(&conf, "Host", "Port", "User")
+	} else {
+		err = validator.New().Struct(&conf)
+	}
+	if err != nil {
 		err = errors.New(fmt.Sprintf("invalid %s KV value, err: %v", key, err.Error()))
 		return
 	}
 
-	conf.Dialect = strings.ToLower(conf.Dialect)
 	switch conf.Dialect {
 	case "mysql":
-		db, err = connectToMysql(conf)
+		db, err = connectToMysql(conf, password)
+	case "sqlite":
+		db, err = connectToSqlite(conf.DB)
 	default:
 		err = errors.New(fmt.Sprintf("%s is not supported db in this service.", conf.Dialect))
 	}
 	return
 }
 
-func connectToMysql(conf ConnectionCfg) (db *gorm.DB, err error) {
-	pwd := os.Getenv("DB_PASSWORD")
-	if pwd == "" {
-		err = errors.New("please set DB_PASSWORD environment variable")
-		return
+// connect to read replicas in connection config returned from ConnectWithConsul
+func ConnectReplicas(conf ConnectionCfg, password string) (replicas []*gorm.DB, err error) {
+	for _, replicaCfg := range conf.Replicas {
+		replicaConf := conf
+		replicaConf.Host, replicaConf.Port = replicaCfg.Host, replicaCfg.Port
+
+		var replica *gorm.DB
+		switch conf.Dialect {
+		case "mysql":
+			replica, err = connectToMysql(replicaConf, password)
+		default:
+			err = errors.New(fmt.Sprintf("%s is not supported db in this service.", conf.Dialect))
+		}
+		if err != nil {
+			err = errors.New(fmt.Sprintf("unable to connect to replica, host: %s, err: %v", replicaCfg.Host, err))
+			return
+		}
+		replicas = append(replicas, replica)
 	}
+	return
+}
+
+// connect to db with DSN directly without consul, used in standalone mode
+func Connect(dialect, dsn string) (db *gorm.DB, err error) {
+	switch strings.ToLower(dialect) {
+	case "mysql":
+		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+	case "sqlite":
+		db, err = connectToSqlite(dsn)
+	default:
+		err = errors.New(fmt.Sprintf("%s is not supported db in this service.", dialect))
+	}
+	return
+}
+
+func connectToMysql(conf ConnectionCfg, pwd string) (db *gorm.DB, err error) {
 	dns := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local", conf.User, pwd, conf.Host, conf.Port, conf.DB)
 	db, err = gorm.Open(mysql.Open(dns), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
@@ -1,34 +1,200 @@
+// migrate.go is file to declare runner of versioned migrations declared in migrations.go
+// applied version is recorded in schema_migrations table, and each migration has up & down step
+
 package db
 
 import (
-	"club/model"
+	"errors"
+	"fmt"
+	log "github.com/micro/go-micro/v2/logger"
 	"gorm.io/gorm"
+	"sort"
+	"time"
 )
 
+// Migration is one versioned change of schema, Down must revert everything done in Up
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+	// DDL like ALTER TABLE commits implicitly in MySQL, so migration having it is run without transaction
+	// such migration must check existence of each object, so that it can be run again after failing in the middle
+	NoTransaction bool
+}
+
+// MigrationState is state of migration returned from MigrationStatus
+type MigrationState struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// migration is applied in DB, but not declared in this binary
+	Unknown bool
+}
+
+// row of schema_migrations table recording applied migration
+type schemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"Type:varchar(100);NOT NULL"`
+	AppliedAt time.Time `gorm:"NOT NULL"`
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+// apply all pending migrations, used in startup with auto migration mode & test
 func Migrate(db *gorm.DB) (err error) {
-	migrator := db.Migrator()
+	_, err = MigrateUp(db)
+	return
+}
+
+// apply pending migrations in order of version, returns applied migrations
+func MigrateUp(db *gorm.DB) ([]Migration, error) {
+	return migrateUp(db, migrations)
+}
+
+// roll back latest applied migrations as many as steps, returns rolled back migrations
+func MigrateDown(db *gorm.DB, steps int) ([]Migration, error) {
+	return migrateDown(db, migrations, steps)
+}
+
+// returns state of all declared & applied migrations in order of version
+func MigrationStatus(db *gorm.DB) ([]MigrationState, error) {
+	return migrationStatus(db, migrations)
+}
 
-	//_ = migrator.DropTable(&model.ClubMember{})
-	//_ = migrator.DropTable(&model.ClubInform{})
-	//_ = migrator.DropTable(&model.RecruitMember{})
-	//_ = migrator.DropTable(&model.ClubRecruitment{})
-	//_ = migrator.DropTable(&model.Club{})
+// returns declared migrations not applied yet, used to refuse boot in check migration mode
+func PendingMigrations(db *gorm.DB) ([]Migration, error) {
+	return pendingMigrations(db, migrations)
+}
 
-	if !migrator.HasTable(&model.Club{}) {
-		if err = migrator.CreateTable(&model.Club{}); err != nil { return }
+func migrateUp(db *gorm.DB, declared []Migration) (applied []Migration, err error) {
+	pending, err := pendingMigrations(db, declared)
+	if err != nil {
+		return
 	}
-	if !migrator.HasTable(&model.ClubInform{}) {
-		if err = migrator.CreateTable(&model.ClubInform{}); err != nil { return }
+
+	for _, migration := range pending {
+		err = runMigrationStep(db, migration, migration.Up, func(tx *gorm.DB) error {
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			err = errors.New(fmt.Sprintf("unable to apply migration %d (%s), err: %v", migration.Version, migration.Name, err))
+			return
+		}
+		log.Infof("migration applied!! (version: %d, name: %s)", migration.Version, migration.Name)
+		applied = append(applied, migration)
 	}
-	if !migrator.HasTable(&model.ClubMember{}) {
-		if err = migrator.CreateTable(&model.ClubMember{}); err != nil { return }
+	return
+}
+
+func migrateDown(db *gorm.DB, declared []Migration, steps int) (rolledBack []Migration, err error) {
+	records, err := appliedMigrations(db)
+	if err != nil {
+		return
 	}
-	if !migrator.HasTable(&model.ClubRecruitment{}) {
-		if err = migrator.CreateTable(&model.ClubRecruitment{}); err != nil { return }
+
+	declaredByVersion := map[uint]Migration{}
+	for _, migration := range declared {
+		declaredByVersion[migration.Version] = migration
 	}
-	if !migrator.HasTable(&model.RecruitMember{}) {
-		if err = migrator.CreateTable(&model.RecruitMember{}); err != nil { return }
+
+	for i := len(records) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		migration, ok := declaredByVersion[records[i].Version]
+		if !ok {
+			err = errors.New(fmt.Sprintf("migration %d (%s) is not declared in this binary, so it can't be rolled back", records[i].Version, records[i].Name))
+			return
+		}
+
+		err = runMigrationStep(db, migration, migration.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			err = errors.New(fmt.Sprintf("unable to roll back migration %d (%s), err: %v", migration.Version, migration.Name, err))
+			return
+		}
+		log.Infof("migration rolled back!! (version: %d, name: %s)", migration.Version, migration.Name)
+		rolledBack = append(rolledBack, migration)
 	}
+	return
+}
+
+func migrationStatus(db *gorm.DB, declared []Migration) (states []MigrationState, err error) {
+	records, err := appliedMigrations(db)
+	if err != nil {
+		return
+	}
+
+	appliedByVersion := map[uint]schemaMigration{}
+	for _, record := range records {
+		appliedByVersion[record.Version] = record
+	}
+
+	for _, migration := range sortedMigrations(declared) {
+		state := MigrationState{Version: migration.Version, Name: migration.Name}
+		if record, ok := appliedByVersion[migration.Version]; ok {
+			state.Applied, state.AppliedAt = true, record.AppliedAt
+			delete(appliedByVersion, migration.Version)
+		}
+		states = append(states, state)
+	}
+	for _, record := range appliedByVersion {
+		states = append(states, MigrationState{Version: record.Version, Name: record.Name, Applied: true, AppliedAt: record.AppliedAt, Unknown: true})
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Version < states[j].Version })
+	return
+}
+
+func pendingMigrations(db *gorm.DB, declared []Migration) (pending []Migration, err error) {
+	states, err := migrationStatus(db, declared)
+	if err != nil {
+		return
+	}
+
+	declaredByVersion := map[uint]Migration{}
+	for _, migration := range declared {
+		declaredByVersion[migration.Version] = migration
+	}
+	for _, state := range states {
+		if !state.Applied {
+			pending = append(pending, declaredByVersion[state.Version])
+		}
+	}
+	return
+}
+
+// run up or down step of migration & record it, in one transaction unless migration is declared with NoTransaction
+func runMigrationStep(db *gorm.DB, migration Migration, step, record func(tx *gorm.DB) error) error {
+	run := func(tx *gorm.DB) error {
+		if err := step(tx); err != nil {
+			return err
+		}
+		return record(tx)
+	}
+	if migration.NoTransaction {
+		return run(db)
+	}
+	return db.Transaction(run)
+}
+
+// returns migrations recorded in schema_migrations table in order of version, table is created if not exists
+func appliedMigrations(db *gorm.DB) (records []schemaMigration, err error) {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		if err = db.Migrator().CreateTable(&schemaMigration{}); err != nil {
+			err = errors.New(fmt.Sprintf("unable to create schema_migrations table, err: %v", err))
+			return
+		}
+	}
+
+	if err = db.Order("version").Find(&records).Error; err != nil {
+		err = errors.New(fmt.Sprintf("unable to select applied migrations, err: %v", err))
+	}
+	return
+}
 
-	return db.AutoMigrate(&model.Club{}, &model.ClubInform{}, &model.ClubMember{}, &model.ClubRecruitment{}, &model.RecruitMember{})
+func sortedMigrations(declared []Migration) (sorted []Migration) {
+	sorted = append(sorted, declared...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return
 }
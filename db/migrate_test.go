@@ -0,0 +1,130 @@
+package db
+
+import (
+	"club/model"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type migrateTestTable struct {
+	ID   uint
+	Name string
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "create test table",
+			Up:      func(tx *gorm.DB) error { return tx.Migrator().CreateTable(&migrateTestTable{}) },
+			Down:    func(tx *gorm.DB) error { return tx.Migrator().DropTable(&migrateTestTable{}) },
+		}, {
+			Version: 2,
+			Name:    "add test index",
+			Up:      func(tx *gorm.DB) error { return tx.Exec("CREATE INDEX idx_name ON migrate_test_tables(name)").Error },
+			Down:    func(tx *gorm.DB) error { return tx.Exec("DROP INDEX idx_name").Error },
+		},
+	}
+}
+
+func Test_migrateUp_migrateDown(t *testing.T) {
+	testDB, err := connectToSqlite("file:migrate_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+	declared := testMigrations()
+
+	applied, err := migrateUp(testDB, declared)
+	assert.Nil(t, err)
+	assert.Len(t, applied, 2)
+	assert.True(t, testDB.Migrator().HasTable(&migrateTestTable{}))
+
+	// already applied migrations are not applied again
+	applied, err = migrateUp(testDB, declared)
+	assert.Nil(t, err)
+	assert.Len(t, applied, 0)
+
+	rolledBack, err := migrateDown(testDB, declared, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(2), rolledBack[0].Version)
+
+	pending, err := pendingMigrations(testDB, declared)
+	assert.Nil(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, uint(2), pending[0].Version)
+
+	rolledBack, err = migrateDown(testDB, declared, 5)
+	assert.Nil(t, err)
+	assert.Len(t, rolledBack, 1)
+	assert.False(t, testDB.Migrator().HasTable(&migrateTestTable{}))
+
+	states, err := migrationStatus(testDB, declared)
+	assert.Nil(t, err)
+	assert.Equal(t, []MigrationState{{Version: 1, Name: "create test table"}, {Version: 2, Name: "add test index"}}, states)
+}
+
+func Test_migrateUp_Failure(t *testing.T) {
+	testDB, err := connectToSqlite("file:migrate_failure_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+	declared := append(testMigrations(), Migration{
+		Version: 3,
+		Name:    "failing migration",
+		Up:      func(tx *gorm.DB) error { return errors.New("failed") },
+		Down:    func(tx *gorm.DB) error { return nil },
+	})
+
+	applied, err := migrateUp(testDB, declared)
+	assert.NotNil(t, err)
+	assert.Len(t, applied, 2)
+
+	// failed migration is not recorded, so it is still pending
+	pending, err := pendingMigrations(testDB, declared)
+	assert.Nil(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, uint(3), pending[0].Version)
+
+	// migration applied but not declared in binary can't be rolled back
+	_, err = migrateDown(testDB, testMigrations()[:1], 1)
+	assert.NotNil(t, err)
+}
+
+func Test_migrations_AliveUniqueIndexes(t *testing.T) {
+	testDB, err := connectToSqlite("file:alive_unique_index_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+	assert.Nil(t, Migrate(testDB))
+
+	// rows are inserted with raw query, so that pre-check in hook is not executed
+	insertClub := "INSERT INTO clubs (uuid, leader_uuid, created_at, updated_at) VALUES (?, ?, ?, ?)"
+	assert.Nil(t, testDB.Exec(insertClub, "club-111111111111", "student-111111111111", time.Now(), time.Now()).Error)
+	assert.NotNil(t, testDB.Exec(insertClub, "club-222222222222", "student-111111111111", time.Now(), time.Now()).Error)
+
+	insertMember := "INSERT INTO club_members (club_uuid, student_uuid, created_at, updated_at) VALUES (?, ?, ?, ?)"
+	assert.Nil(t, testDB.Exec(insertMember, "club-111111111111", "student-222222222222", time.Now(), time.Now()).Error)
+	assert.NotNil(t, testDB.Exec(insertMember, "club-111111111111", "student-222222222222", time.Now(), time.Now()).Error)
+
+	// soft deleted row doesn't conflict with new row
+	assert.Nil(t, testDB.Exec("UPDATE clubs SET deleted_at = ? WHERE uuid = ?", time.Now(), "club-111111111111").Error)
+	assert.Nil(t, testDB.Exec(insertClub, "club-222222222222", "student-111111111111", time.Now(), time.Now()).Error)
+
+	rolledBack, err := MigrateDown(testDB, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(3), rolledBack[0].Version)
+	assert.False(t, testDB.Migrator().HasIndex(&model.Club{}, model.ClubInstance.LeaderUUID.KeyName()))
+	assert.False(t, testDB.Migrator().HasIndex(&model.ClubMember{}, model.ClubMemberInstance.StudentUUID.KeyName()))
+}
+
+func Test_migrations_AliveUniqueIndexes_PartiallyApplied(t *testing.T) {
+	testDB, err := connectToSqlite("file:alive_unique_index_partial_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+	_, err = migrateUp(testDB, migrations[:2])
+	assert.Nil(t, err)
+
+	// migration 3 runs without transaction, so index created before failing in the middle remains
+	assert.Nil(t, createAliveUniqueIndex(testDB, aliveUniqueIndexes[0]))
+
+	applied, err := MigrateUp(testDB)
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+	assert.True(t, testDB.Migrator().HasIndex(&model.ClubMember{}, model.ClubMemberInstance.StudentUUID.KeyName()))
+}
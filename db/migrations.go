@@ -0,0 +1,155 @@
+// migrations.go is file to declare versioned migrations applied by runner in migrate.go
+// new migration must be appended with next version, and applied migration must not be modified
+
+package db
+
+import (
+	"club/model"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+)
+
+var migrations = []Migration{
+	{
+		// tables are created only if not exist, so that DB created with AutoMigrate before is regarded as version 1
+		Version: 1,
+		Name:    "create club tables",
+		Up: func(tx *gorm.DB) (err error) {
+			migrator := tx.Migrator()
+			for _, table := range []interface{}{&model.Club{}, &model.ClubInform{}, &model.ClubMember{}, &model.ClubRecruitment{}, &model.RecruitMember{}} {
+				if migrator.HasTable(table) {
+					continue
+				}
+				if err = migrator.CreateTable(table); err != nil {
+					return
+				}
+			}
+			return
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&model.RecruitMember{}, &model.ClubRecruitment{}, &model.ClubMember{}, &model.ClubInform{}, &model.Club{})
+		},
+	}, {
+		// column is added only if not exist, because tables created in version 1 with current model already have it
+		Version: 2,
+		Name:    "add version column for optimistic concurrency control",
+		Up: func(tx *gorm.DB) (err error) {
+			migrator := tx.Migrator()
+			for _, table := range []interface{}{&model.ClubInform{}, &model.ClubRecruitment{}} {
+				if migrator.HasColumn(table, "Version") {
+					continue
+				}
+				if err = migrator.AddColumn(table, "Version"); err != nil {
+					return
+				}
+			}
+			return
+		},
+		Down: func(tx *gorm.DB) (err error) {
+			migrator := tx.Migrator()
+			for _, table := range []interface{}{&model.ClubRecruitment{}, &model.ClubInform{}} {
+				if err = migrator.DropColumn(table, "Version"); err != nil {
+					return
+				}
+			}
+			return
+		},
+	}, {
+		// uniqueness checked in BeforeCreate & BeforeUpdate hook can be broken by concurrent requests, so it is guaranteed by index
+		// run without transaction because DDL of MySQL commits implicitly, and each column & index is created only if not exist
+		Version:       3,
+		Name:          "add unique indexes ignoring soft deleted rows",
+		NoTransaction: true,
+		Up: func(tx *gorm.DB) (err error) {
+			for _, index := range aliveUniqueIndexes {
+				if err = createAliveUniqueIndex(tx, index); err != nil {
+					return
+				}
+			}
+			return
+		},
+		Down: func(tx *gorm.DB) (err error) {
+			for i := len(aliveUniqueIndexes) - 1; i >= 0; i-- {
+				if err = dropAliveUniqueIndex(tx, aliveUniqueIndexes[i]); err != nil {
+					return
+				}
+			}
+			return dropAliveColumns(tx)
+		},
+	},
+}
+
+// unique index applied only to rows not soft deleted
+// index is named after key name of model, so that duplicate entry error from DB has same key with error returned from hook
+type aliveUniqueIndex struct {
+	Model   interface{ TableName() string }
+	Name    string
+	Columns []string
+}
+
+var aliveUniqueIndexes = []aliveUniqueIndex{
+	{Model: &model.Club{}, Name: model.ClubInstance.LeaderUUID.KeyName(), Columns: []string{"leader_uuid"}},
+	{Model: &model.ClubInform{}, Name: model.ClubInformInstance.Name.KeyName(), Columns: []string{"name"}},
+	{Model: &model.ClubInform{}, Name: model.ClubInformInstance.Location.KeyName(), Columns: []string{"location"}},
+	{Model: &model.ClubMember{}, Name: model.ClubMemberInstance.StudentUUID.KeyName(), Columns: []string{"club_uuid", "student_uuid"}},
+}
+
+// name of generated column having 1 in row not soft deleted & NULL in soft deleted row, used only in MySQL
+const aliveColumn = "alive"
+
+// MySQL doesn't support partial index, so generated alive column is added to index instead
+// NULL is not regarded as duplicate in unique index, so soft deleted rows never conflict with others
+func createAliveUniqueIndex(tx *gorm.DB, index aliveUniqueIndex) (err error) {
+	columns := strings.Join(index.Columns, ", ")
+	if tx.Dialector.Name() == "sqlite" {
+		return tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS `%s` ON `%s` (%s) WHERE deleted_at IS NULL", index.Name, index.Model.TableName(), columns)).Error
+	}
+
+	if !tx.Migrator().HasColumn(index.Model, aliveColumn) {
+		alter := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` TINYINT(1) AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL", index.Model.TableName(), aliveColumn)
+		if err = tx.Exec(alter).Error; err != nil {
+			return
+		}
+	}
+
+	exist, err := mysqlIndexExists(tx, index)
+	if err != nil || exist {
+		return
+	}
+	return tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX `%s` ON `%s` (%s, `%s`)", index.Name, index.Model.TableName(), columns, aliveColumn)).Error
+}
+
+func dropAliveUniqueIndex(tx *gorm.DB, index aliveUniqueIndex) (err error) {
+	if tx.Dialector.Name() == "sqlite" {
+		return tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS `%s`", index.Name)).Error
+	}
+
+	exist, err := mysqlIndexExists(tx, index)
+	if err != nil || !exist {
+		return
+	}
+	return tx.Exec(fmt.Sprintf("DROP INDEX `%s` ON `%s`", index.Name, index.Model.TableName())).Error
+}
+
+// MySQL doesn't support IF NOT EXISTS in CREATE INDEX, so existence of index is checked in information_schema
+func mysqlIndexExists(tx *gorm.DB, index aliveUniqueIndex) (exist bool, err error) {
+	var count int64
+	err = tx.Raw("SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?",
+		index.Model.TableName(), index.Name).Scan(&count).Error
+	exist = count != 0
+	return
+}
+
+// drop alive column after all unique indexes using it are dropped
+func dropAliveColumns(tx *gorm.DB) (err error) {
+	for _, index := range aliveUniqueIndexes {
+		if !tx.Migrator().HasColumn(index.Model, aliveColumn) {
+			continue
+		}
+		if err = tx.Exec(fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`", index.Model.TableName(), aliveColumn)).Error; err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,246 @@
+// replica.go is file to declare read replica of AccessorManage, used for read-only RPC
+// health & lag of each replica is checked periodically, and primary is used if there is no available replica
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	log "github.com/micro/go-micro/v2/logger"
+	"gorm.io/gorm"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxReplicaLag        = time.Second * 5
+	defaultReplicaCheckInterval = time.Second * 5
+	defaultReplicaCheckTimeout  = time.Second * 3
+)
+
+// function to check replica, returns replication lag of replica or error if replica is down or ctx is done
+type ReplicaChecker func(ctx context.Context) (lag time.Duration, err error)
+
+type replica struct {
+	name          string
+	accessorType  reflect.Type
+	accessorValue reflect.Value
+	check         ReplicaChecker
+	available     bool
+}
+
+type replicaSet struct {
+	replicas      []*replica
+	maxLag        time.Duration
+	checkInterval time.Duration
+	checkTimeout  time.Duration
+	next          int
+	mutex         sync.Mutex
+
+	monitorCancel context.CancelFunc
+	monitorGroup  sync.WaitGroup
+	monitorMutex  sync.Mutex
+}
+
+type AccessorManageFieldSetter func(*AccessorManage)
+
+// add read replica with accessor connected to replica, check is used for checking health & lag of replica
+func ReadReplica(name string, accessor Accessor, check ReplicaChecker) AccessorManageFieldSetter {
+	return func(atm *AccessorManage) {
+		accessorType, accessorValue := reflect.TypeOf(accessor), reflect.ValueOf(accessor)
+		if accessorType.Kind() == reflect.Ptr {
+			accessorType, accessorValue = accessorType.Elem(), accessorValue.Elem()
+		}
+		atm.replicas.replicas = append(atm.replicas.replicas, &replica{
+			name:          name,
+			accessorType:  accessorType,
+			accessorValue: accessorValue,
+			check:         check,
+		})
+	}
+}
+
+// set max replication lag of available replica, replica lagging more than this is not used
+func MaxReplicaLag(lag time.Duration) AccessorManageFieldSetter {
+	return func(atm *AccessorManage) {
+		atm.replicas.maxLag = lag
+	}
+}
+
+func ReplicaCheckInterval(interval time.Duration) AccessorManageFieldSetter {
+	return func(atm *AccessorManage) {
+		atm.replicas.checkInterval = interval
+	}
+}
+
+// set deadline of each check, replica not responding until deadline is regarded as unavailable
+func ReplicaCheckTimeout(timeout time.Duration) AccessorManageFieldSetter {
+	return func(atm *AccessorManage) {
+		atm.replicas.checkTimeout = timeout
+	}
+}
+
+// return accessor of available replica in round robin with transaction begun, or accessor of primary if there is none
+func (atm AccessorManage) BeginReadOnlyTx() (accessor Accessor) {
+	if r := atm.replicas.selectAvailable(); r != nil {
+		newAccessor := reflect.New(r.accessorType)
+		newAccessor.Elem().Set(r.accessorValue)
+
+		accessor = newAccessor.Interface().(Accessor)
+		accessor.BeginTx()
+		return
+	}
+	return atm.BeginTx()
+}
+
+// check all replicas once & start goroutine checking replicas periodically, used with micro.BeforeStart
+// so that available replicas are known before the first read-only RPC is handled
+func (atm AccessorManage) StartReplicaMonitor() (_ error) {
+	rs := atm.replicas
+	if rs == nil {
+		return
+	}
+	rs.monitorMutex.Lock()
+	defer rs.monitorMutex.Unlock()
+
+	if rs.monitorCancel != nil || len(rs.replicas) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rs.checkAll(ctx)
+	rs.monitorCancel = cancel
+	rs.monitorGroup.Add(1)
+	go rs.monitor(ctx)
+	return
+}
+
+// stop goroutine started in StartReplicaMonitor & wait for it to return
+func (atm AccessorManage) StopReplicaMonitor() (_ error) {
+	rs := atm.replicas
+	if rs == nil {
+		return
+	}
+	rs.monitorMutex.Lock()
+	defer rs.monitorMutex.Unlock()
+
+	if rs.monitorCancel != nil {
+		rs.monitorCancel()
+		rs.monitorGroup.Wait()
+		rs.monitorCancel = nil
+	}
+	return
+}
+
+func (rs *replicaSet) monitor(ctx context.Context) {
+	defer rs.monitorGroup.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rs.checkInterval):
+		}
+		rs.checkAll(ctx)
+	}
+}
+
+// check health & lag of all replicas with deadline per check, mutex is locked only when availability is updated
+func (rs *replicaSet) checkAll(ctx context.Context) {
+	for _, r := range rs.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, rs.checkTimeout)
+		lag, err := r.check(checkCtx)
+		cancel()
+		available := err == nil && lag <= rs.maxLag
+
+		rs.mutex.Lock()
+		changed := available != r.available
+		r.available = available
+		rs.mutex.Unlock()
+
+		if changed {
+			if available {
+				log.Infof("read replica become available, replica: %s, lag: %s", r.name, lag)
+			} else {
+				log.Warnf("read replica become unavailable, use other replica or primary, replica: %s, lag: %s, err: %v", r.name, lag, err)
+			}
+		}
+	}
+}
+
+func (rs *replicaSet) selectAvailable() *replica {
+	if rs == nil {
+		return nil
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	for range rs.replicas {
+		r := rs.replicas[rs.next%len(rs.replicas)]
+		rs.next++
+		if r.available {
+			return r
+		}
+	}
+	return nil
+}
+
+// return ReplicaChecker pinging MySQL replica & reading Seconds_Behind_Master in SHOW SLAVE STATUS
+// SHOW SLAVE STATUS is queried with QueryContext instead of QueryRowContext, because its columns differ by MySQL version
+func MysqlReplicaChecker(replicaDB *gorm.DB) ReplicaChecker {
+	return func(ctx context.Context) (lag time.Duration, err error) {
+		sqlDB, err := replicaDB.DB()
+		if err != nil {
+			return
+		}
+		if err = sqlDB.PingContext(ctx); err != nil {
+			err = errors.New(fmt.Sprintf("unable to ping replica, err: %v", err))
+			return
+		}
+
+		rows, err := sqlDB.QueryContext(ctx, "SHOW SLAVE STATUS")
+		if err != nil {
+			err = errors.New(fmt.Sprintf("unable to query slave status, err: %v", err))
+			return
+		}
+		defer func() { _ = rows.Close() }()
+
+		// replication is not configured in this host, regarded as replica without lag
+		if !rows.Next() {
+			return
+		}
+		columns, err := rows.Columns()
+		if err != nil {
+			return
+		}
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return
+		}
+
+		for i, column := range columns {
+			if column != "Seconds_Behind_Master" {
+				continue
+			}
+			if !values[i].Valid {
+				err = errors.New("replication of replica is stopped, Seconds_Behind_Master is NULL")
+				return
+			}
+			seconds, parseErr := strconv.Atoi(values[i].String)
+			if parseErr != nil {
+				err = errors.New(fmt.Sprintf("unable to parse Seconds_Behind_Master, err: %v", parseErr))
+				return
+			}
+			lag = time.Duration(seconds) * time.Second
+			return
+		}
+		return
+	}
+}
@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"club/db"
+	"club/db/access"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func Test_AccessorManage_BeginReadOnlyTx(t *testing.T) {
+	tests := []struct {
+		ReplicaLag      time.Duration
+		ReplicaErr      error
+		ExpectedReplica bool
+	}{
+		{ // available replica -> replica is used
+			ReplicaLag:      time.Second,
+			ExpectedReplica: true,
+		}, { // replica too far behind -> fallback to primary
+			ReplicaLag:      time.Minute,
+			ExpectedReplica: false,
+		}, { // replica is down -> fallback to primary
+			ReplicaErr:      errors.New("connection refused"),
+			ExpectedReplica: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		primaryMock, replicaMock := new(mock.Mock), new(mock.Mock)
+		primaryMock.On("BeginTx").Return()
+		replicaMock.On("BeginTx").Return()
+
+		manager, err := db.NewAccessorManage(access.Mock(primaryMock),
+			db.ReadReplica("replica-1", access.Mock(replicaMock), func(context.Context) (time.Duration, error) {
+				return testCase.ReplicaLag, testCase.ReplicaErr
+			}),
+			db.MaxReplicaLag(time.Second*5),
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, manager.StartReplicaMonitor())
+
+		manager.BeginReadOnlyTx()
+		assert.Nil(t, manager.StopReplicaMonitor())
+
+		if testCase.ExpectedReplica {
+			replicaMock.AssertCalled(t, "BeginTx")
+			primaryMock.AssertNotCalled(t, "BeginTx")
+		} else {
+			primaryMock.AssertCalled(t, "BeginTx")
+			replicaMock.AssertNotCalled(t, "BeginTx")
+		}
+	}
+}
+
+func Test_AccessorManage_BeginReadOnlyTx_WithoutReplica(t *testing.T) {
+	primaryMock := new(mock.Mock)
+	primaryMock.On("BeginTx").Return()
+
+	manager, err := db.NewAccessorManage(access.Mock(primaryMock))
+	assert.Nil(t, err)
+
+	manager.BeginReadOnlyTx()
+	primaryMock.AssertCalled(t, "BeginTx")
+}
+
+func Test_AccessorManage_StartReplicaMonitor_CheckTimeout(t *testing.T) {
+	primaryMock, replicaMock := new(mock.Mock), new(mock.Mock)
+	primaryMock.On("BeginTx").Return()
+	replicaMock.On("BeginTx").Return()
+
+	// replica not responding is regarded as unavailable when deadline of check is exceeded
+	manager, err := db.NewAccessorManage(access.Mock(primaryMock),
+		db.ReadReplica("replica-1", access.Mock(replicaMock), func(ctx context.Context) (time.Duration, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}),
+		db.ReplicaCheckTimeout(time.Millisecond*10),
+	)
+	assert.Nil(t, err)
+
+	started := time.Now()
+	assert.Nil(t, manager.StartReplicaMonitor())
+	assert.True(t, time.Since(started) < time.Second, "StartReplicaMonitor must not be blocked by replica not responding")
+
+	manager.BeginReadOnlyTx()
+	assert.Nil(t, manager.StopReplicaMonitor())
+
+	primaryMock.AssertCalled(t, "BeginTx")
+	replicaMock.AssertNotCalled(t, "BeginTx")
+}
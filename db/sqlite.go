@@ -0,0 +1,111 @@
+// sqlite.go is file to declare connection to SQLite, used in integration test & local run without MySQL
+// constraint error of SQLite is translated into MySQL error, so that handler & test can handle error in same way
+
+package db
+
+import (
+	"club/tool/mysqlerr"
+	"errors"
+	"fmt"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strings"
+)
+
+// name of database in SQLite, used as DB name of translated FK constraint fail error
+const sqliteDBName = "main"
+
+// connect to SQLite with file path or DSN like "file::memory:?cache=shared"
+func connectToSqlite(dsn string) (db *gorm.DB, err error) {
+	db, err = gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return
+	}
+
+	// SQLite allows only one writer, and each connection of in-memory DB has its own DB
+	sqlDB, err := db.DB()
+	if err != nil {
+		return
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err = db.Exec("PRAGMA foreign_keys = ON").Error; err != nil {
+		err = errors.New(fmt.Sprintf("unable to enable foreign key in sqlite, err: %v", err))
+		return
+	}
+
+	translate := func(tx *gorm.DB) { tx.Error = translateSqliteError(tx.Statement, tx.Error) }
+	if err = db.Callback().Create().After("gorm:create").Register("club:translate_sqlite_error", translate); err != nil {
+		return
+	}
+	err = db.Callback().Update().After("gorm:update").Register("club:translate_sqlite_error", translate)
+	return
+}
+
+// translate constraint error of SQLite into MySQL error having same format with error returned from MySQL
+func translateSqliteError(stmt *gorm.Statement, err error) error {
+	sqliteErr, ok := err.(sqlite3.Error)
+	if !ok || stmt.Schema == nil {
+		return err
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		// message format: UNIQUE constraint failed: clubs.uuid (or club_members.club_uuid, club_members.student_uuid)
+		// composite unique index is named after its last column, and its entry is values joined with '.' like hook of model
+		columns := strings.Split(sqliteErr.Error()[strings.LastIndex(sqliteErr.Error(), ":")+1:], ",")
+		entries := make([]string, len(columns))
+		for i := range columns {
+			column := strings.TrimSpace(columns[i])
+			columns[i] = column[strings.LastIndex(column, ".")+1:]
+
+			field := stmt.Schema.LookUpField(columns[i])
+			if field == nil {
+				return err
+			}
+			entries[i] = fmt.Sprint(fieldValueOf(stmt, field))
+		}
+		return mysqlerr.DuplicateEntry(columns[len(columns)-1], strings.Join(entries, "."))
+
+	case sqlite3.ErrConstraintForeignKey:
+		// SQLite doesn't report which constraint fails, so first belongs to relation of model is regarded as failed one
+		for _, relation := range stmt.Schema.Relationships.BelongsTo {
+			constraint := relation.ParseConstraint()
+			if constraint == nil || len(constraint.ForeignKeys) == 0 {
+				continue
+			}
+			return mysqlerr.FKConstraintFailWithoutReferenceInform(mysqlerr.FKInform{
+				DBName:         sqliteDBName,
+				TableName:      stmt.Schema.Table,
+				ConstraintName: constraint.Name,
+				AttrName:       constraint.ForeignKeys[0].DBName,
+			}, mysqlerr.RefInform{
+				TableName: constraint.ReferenceSchema.Table,
+				AttrName:  constraint.References[0].DBName,
+			})
+		}
+	}
+	return err
+}
+
+// get value of field in model saved with statement, first element is used if slice of model is saved
+func fieldValueOf(stmt *gorm.Statement, field *schema.Field) interface{} {
+	reflectValue := stmt.ReflectValue
+	if reflectValue.Kind() == reflect.Slice || reflectValue.Kind() == reflect.Array {
+		if reflectValue.Len() == 0 {
+			return ""
+		}
+		reflectValue = reflect.Indirect(reflectValue.Index(0))
+	}
+	if reflectValue.Kind() != reflect.Struct {
+		return ""
+	}
+	value, _ := field.ValueOf(reflectValue)
+	return value
+}
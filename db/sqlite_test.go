@@ -0,0 +1,45 @@
+package db
+
+import (
+	"club/tool/mysqlerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type sqliteTestMember struct {
+	ID          uint
+	ClubUUID    string
+	StudentUUID string
+}
+
+func Test_translateSqliteError_UniqueConstraint(t *testing.T) {
+	testDB, err := connectToSqlite("file:sqlite_translate_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+	assert.Nil(t, testDB.Migrator().CreateTable(&sqliteTestMember{}))
+	assert.Nil(t, testDB.Exec("CREATE UNIQUE INDEX club_uuid ON sqlite_test_members (club_uuid) WHERE student_uuid = ''").Error)
+	assert.Nil(t, testDB.Exec("CREATE UNIQUE INDEX student_uuid ON sqlite_test_members (club_uuid, student_uuid) WHERE student_uuid != ''").Error)
+
+	tests := []struct {
+		ClubUUID, StudentUUID string
+		ExpectedError         error
+	}{
+		{ // success case
+			ClubUUID:    "club-111111111111",
+			StudentUUID: "student-111111111111",
+		}, { // composite unique index -> named after last column, entry joined with '.'
+			ClubUUID:      "club-111111111111",
+			StudentUUID:   "student-111111111111",
+			ExpectedError: mysqlerr.DuplicateEntry("student_uuid", "club-111111111111.student-111111111111"),
+		}, { // success case (empty student uuid)
+			ClubUUID: "club-222222222222",
+		}, { // single column unique index
+			ClubUUID:      "club-222222222222",
+			ExpectedError: mysqlerr.DuplicateEntry("club_uuid", "club-222222222222"),
+		},
+	}
+
+	for _, testCase := range tests {
+		err := testDB.Create(&sqliteTestMember{ClubUUID: testCase.ClubUUID, StudentUUID: testCase.StudentUUID}).Error
+		assert.Equalf(t, testCase.ExpectedError, err, "error assertion error (test case: %v)", testCase)
+	}
+}
@@ -4,15 +4,25 @@ import (
 	"club/db"
 	"club/model"
 	"club/tool/mysqlerr"
-	"strings"
+	"context"
+	"gorm.io/gorm"
 )
 
 var manager db.AccessorManage
 
+// connection used to run raw query bypassing hooks of model, ex. checking constraint of DB itself
+var testDB *gorm.DB
+
+// context passed to accessor methods in integration test
+var testCtx = context.Background()
+
+// count of select query executed in integration test, used to assert that batch accessor selects with one query
+var queryCount int64
+
 
 var (
 	clubInformClubUUIDFKConstraintFailError = mysqlerr.FKConstraintFailWithoutReferenceInform(mysqlerr.FKInform{
-		DBName:         strings.ToLower("SMS_Club_Test_DB"),
+		DBName:         testDBName,
 		TableName:      model.ClubInformInstance.TableName(),
 		ConstraintName: model.ClubInformInstance.ClubUUIDConstraintName(),
 		AttrName:       model.ClubInformInstance.ClubUUID.KeyName(),
@@ -22,7 +32,7 @@ var (
 	})
 
 	clubMemberClubUUIDFKConstraintFailError = mysqlerr.FKConstraintFailWithoutReferenceInform(mysqlerr.FKInform{
-		DBName:         strings.ToLower("SMS_Club_Test_DB"),
+		DBName:         testDBName,
 		TableName:      model.ClubMemberInstance.TableName(),
 		ConstraintName: model.ClubMemberInstance.ClubUUIDConstraintName(),
 		AttrName:       model.ClubMemberInstance.ClubUUID.KeyName(),
@@ -32,7 +42,7 @@ var (
 	})
 
 	clubRecruitmentClubUUIDFKConstraintFailError = mysqlerr.FKConstraintFailWithoutReferenceInform(mysqlerr.FKInform{
-		DBName:         strings.ToLower("SMS_Club_Test_DB"),
+		DBName:         testDBName,
 		TableName:      model.ClubRecruitmentInstance.TableName(),
 		ConstraintName: model.ClubRecruitmentInstance.ClubUUIDConstraintName(),
 		AttrName:       model.ClubRecruitmentInstance.ClubUUID.KeyName(),
@@ -42,7 +52,7 @@ var (
 	})
 
 	recruitMemberRecruitmentUUIDFKConstraintFailError = mysqlerr.FKConstraintFailWithoutReferenceInform(mysqlerr.FKInform{
-		DBName:         strings.ToLower("SMS_Club_Test_DB"),
+		DBName:         testDBName,
 		TableName:      model.RecruitMemberInstance.TableName(),
 		ConstraintName: model.RecruitMemberInstance.RecruitmentUUIDConstraintName(),
 		AttrName:       model.RecruitMemberInstance.RecruitmentUUID.KeyName(),
@@ -4,20 +4,57 @@ import (
 	"club/db"
 	"club/db/access"
 	"github.com/hashicorp/consul/api"
+	"gorm.io/gorm"
 	"log"
+	"os"
+	"strings"
+	"sync/atomic"
 )
 
+// dialect of DB used in integration test, selected with TEST_DB_DIALECT env (sqlite or mysql, default sqlite)
+// SQLite runs with in-memory DB so that test can run without MySQL & consul, MySQL is connected with consul KV
+var testDialect = func() string {
+	if dialect := strings.ToLower(os.Getenv("TEST_DB_DIALECT")); dialect != "" {
+		return dialect
+	}
+	return "sqlite"
+}()
+
+// name of database, used in FK constraint fail error
+var testDBName = func() string {
+	if testDialect == "mysql" {
+		return strings.ToLower("SMS_Club_Test_DB")
+	}
+	return "main"
+}()
+
 func init() {
-	cli, err := api.NewClient(api.DefaultConfig())
+	var (
+		dbc *gorm.DB
+		err error
+	)
+	switch testDialect {
+	case "mysql":
+		var cli *api.Client
+		if cli, err = api.NewClient(api.DefaultConfig()); err != nil {
+			log.Fatal(err)
+		}
+		dbc, _, err = db.ConnectWithConsul(cli, "db/club/local_test", os.Getenv("DB_PASSWORD"))
+	default:
+		dbc, err = db.Connect(testDialect, "file::memory:?cache=shared")
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
+	if err = db.Migrate(dbc); err != nil {
+		log.Fatal(err)
+	}
+	testDB = dbc
 
-	dbc, _, err := db.ConnectDBWithConsul(cli, "db/club/local_test")
-	if err != nil {
+	countQuery := func(*gorm.DB) { atomic.AddInt64(&queryCount, 1) }
+	if err = dbc.Callback().Query().After("gorm:query").Register("test:count_query", countQuery); err != nil {
 		log.Fatal(err)
 	}
-	db.Migrate(dbc)
 
 	manager, err = db.NewAccessorManage(access.Default(dbc))
 	if err != nil {
@@ -3,10 +3,14 @@ package test
 import (
 	"club/model"
 	"club/tool/mysqlerr"
+	"errors"
+	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 	"log"
+	"sync"
 	"testing"
 	"time"
 )
@@ -46,7 +50,7 @@ func Test_Accessor_CreateClub(t *testing.T) {
 	}
 
 	for _, testCase := range tests {
-		_, err := access.CreateClub(&model.Club{
+		_, err := access.CreateClub(testCtx, &model.Club{
 			UUID:       model.UUID(testCase.UUID),
 			LeaderUUID: model.LeaderUUID(testCase.LeaderUUID),
 		})
@@ -78,7 +82,7 @@ func Test_Accessor_CreateClubInform(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -156,7 +160,7 @@ func Test_Accessor_CreateClubInform(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := access.CreateClubInform(&model.ClubInform{
+		_, err := access.CreateClubInform(testCtx, &model.ClubInform{
 			ClubUUID:     model.ClubUUID(test.ClubUUID),
 			Name:         model.Name(test.Name),
 			ClubConcept:  model.ClubConcept(test.ClubConcept),
@@ -196,7 +200,7 @@ func Test_Accessor_CreateClubMember(t *testing.T) {
 			LeaderUUID: "student-432143214321",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -239,7 +243,7 @@ func Test_Accessor_CreateClubMember(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := access.CreateClubMember(&model.ClubMember{
+		_, err := access.CreateClubMember(testCtx, &model.ClubMember{
 			ClubUUID:    model.ClubUUID(test.ClubUUID),
 			StudentUUID: model.StudentUUID(test.StudentUUID),
 		})
@@ -275,7 +279,7 @@ func Test_Accessor_CreateRecruitment(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -335,7 +339,7 @@ func Test_Accessor_CreateRecruitment(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := access.CreateRecruitment(&model.ClubRecruitment{
+		_, err := access.CreateRecruitment(testCtx, &model.ClubRecruitment{
 			UUID:           model.UUID(test.UUID),
 			ClubUUID:       model.ClubUUID(test.ClubUUID),
 			RecruitConcept: model.RecruitConcept(test.RecruitConcept),
@@ -356,6 +360,137 @@ func Test_Accessor_CreateRecruitment(t *testing.T) {
 	}
 }
 
+// unique index must reject duplicate inserted without pre-check of hook, and ignore soft deleted rows
+func Test_UniqueIndexIgnoringSoftDeleted(t *testing.T) {
+	if testDialect != "mysql" {
+		// error of raw query isn't translated in SQLite, and SQLite uses partial index instead of alive column
+		t.Skip("duplicate entry error of unique index can be tested only with MySQL")
+	}
+
+	tx := testDB.Begin()
+	defer func() {
+		tx.Rollback()
+	}()
+
+	insertClub := "INSERT INTO clubs (uuid, leader_uuid, created_at, updated_at) VALUES (?, ?, NOW(), NOW())"
+	insertMember := "INSERT INTO club_members (club_uuid, student_uuid, created_at, updated_at) VALUES (?, ?, NOW(), NOW())"
+	assert.Nil(t, tx.Exec(insertClub, "club-888888888888", "student-888888888888").Error)
+	assert.Nil(t, tx.Exec(insertMember, "club-888888888888", "student-777777777777").Error)
+
+	tests := []struct {
+		Query       string
+		Args        []interface{}
+		ExpectedKey string
+	}{
+		{ // leader uuid duplicate error
+			Query:       insertClub,
+			Args:        []interface{}{"club-777777777777", "student-888888888888"},
+			ExpectedKey: model.ClubInstance.LeaderUUID.KeyName(),
+		}, { // club member duplicate error
+			Query:       insertMember,
+			Args:        []interface{}{"club-888888888888", "student-777777777777"},
+			ExpectedKey: model.ClubMemberInstance.StudentUUID.KeyName(),
+		},
+	}
+
+	for _, test := range tests {
+		mysqlErr, ok := tx.Exec(test.Query, test.Args...).Error.(*mysql.MySQLError)
+		if !assert.Truef(t, ok, "error type assertion error (test case: %v)", test) {
+			continue
+		}
+		key, _, err := mysqlerr.ParseDuplicateEntryErrorFrom(mysqlErr)
+		assert.Nilf(t, err, "parse error assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedKey, key, "key assertion error (test case: %v)", test)
+	}
+
+	// soft deleted rows don't conflict with new rows
+	assert.Nil(t, tx.Exec("UPDATE club_members SET deleted_at = NOW() WHERE club_uuid = ?", "club-888888888888").Error)
+	assert.Nil(t, tx.Exec("UPDATE clubs SET deleted_at = NOW() WHERE uuid = ?", "club-888888888888").Error)
+	assert.Nil(t, tx.Exec(insertClub, "club-777777777777", "student-888888888888").Error)
+	assert.Nil(t, tx.Exec(insertMember, "club-888888888888", "student-777777777777").Error)
+}
+
+// check & create of recruitment in concurrent transactions must be serialized by locking club row
+func Test_Accessor_CreateRecruitmentConcurrently(t *testing.T) {
+	if testDialect != "mysql" {
+		// SQLite serializes transactions with single connection & has no row lock, so test passes even without locking
+		t.Skip("locking club row can be tested only with MySQL")
+	}
+
+	const (
+		clubUUID    = "club-999999999999"
+		concurrency = 5
+	)
+
+	access := manager.BeginTx()
+	if _, err := access.CreateClub(testCtx, &model.Club{UUID: clubUUID, LeaderUUID: "student-999999999999"}); err != nil {
+		log.Fatal(err)
+	}
+	access.Commit()
+
+	var createdUUIDs []string
+	defer func() {
+		// rows are committed, so they are deleted permanently to run test again with same DB
+		testDB.Unscoped().Where("club_uuid = ?", clubUUID).Delete(&model.ClubRecruitment{})
+		testDB.Unscoped().Where("uuid = ?", clubUUID).Delete(&model.Club{})
+	}()
+
+	errRecruitmentInProgress := errors.New("recruitment is already in progress")
+	register := func(recruitUUID string) (err error) {
+		access := manager.BeginTx()
+		defer func() {
+			if err != nil {
+				access.Rollback()
+			}
+		}()
+
+		if _, err = access.GetClubWithClubUUIDForUpdate(testCtx, clubUUID); err != nil {
+			return
+		}
+		if _, err = access.GetCurrentRecruitmentWithClubUUID(testCtx, clubUUID); err != gorm.ErrRecordNotFound {
+			if err == nil {
+				err = errRecruitmentInProgress
+			}
+			return
+		}
+		if _, err = access.CreateRecruitment(testCtx, &model.ClubRecruitment{
+			UUID:           model.UUID(recruitUUID),
+			ClubUUID:       clubUUID,
+			RecruitConcept: "동시 모집 등록",
+		}); err != nil {
+			return
+		}
+		return access.Commit().Error
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		errs  []error
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(recruitUUID string) {
+			defer wg.Done()
+			err := register(recruitUUID)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err == nil {
+				createdUUIDs = append(createdUUIDs, recruitUUID)
+			}
+			errs = append(errs, err)
+		}(fmt.Sprintf("recruitment-99999999999%d", i))
+	}
+	wg.Wait()
+
+	assert.Len(t, createdUUIDs, 1, "only one recruitment must be created")
+	for _, err := range errs {
+		if err != nil {
+			assert.Equalf(t, errRecruitmentInProgress, err, "error assertion error (errors: %v)", errs)
+		}
+	}
+}
+
 func Test_Accessor_CreateRecruitMember(t *testing.T) {
 	access := manager.BeginTx()
 	defer func() {
@@ -371,7 +506,7 @@ func Test_Accessor_CreateRecruitMember(t *testing.T) {
 			LeaderUUID: "student-432143214321",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -389,7 +524,7 @@ func Test_Accessor_CreateRecruitMember(t *testing.T) {
 			RecruitConcept: "두번쨰 공채",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
@@ -441,7 +576,7 @@ func Test_Accessor_CreateRecruitMember(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := access.CreateRecruitMember(&model.RecruitMember{
+		_, err := access.CreateRecruitMember(testCtx, &model.RecruitMember{
 			RecruitmentUUID: model.RecruitmentUUID(test.RecruitmentUUID),
 			Grade:           model.Grade(test.Grade),
 			Field:           model.Field(test.Field),
@@ -19,7 +19,7 @@ func Test_Accessor_DeleteXXX(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -34,7 +34,7 @@ func Test_Accessor_DeleteXXX(t *testing.T) {
 			LogoURI:  "logo.com/club-111111111111",
 		},
 	} {
-		if _, err := access.CreateClubInform(inform); err != nil {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
 			log.Fatal(err, inform)
 		}
 	}
@@ -51,7 +51,7 @@ func Test_Accessor_DeleteXXX(t *testing.T) {
 			StudentUUID: "student-333333333333",
 		},
 	} {
-		if _, err := access.CreateClubMember(member); err != nil {
+		if _, err := access.CreateClubMember(testCtx, member); err != nil {
 			log.Fatal(err)
 		}
 	}
@@ -63,7 +63,7 @@ func Test_Accessor_DeleteXXX(t *testing.T) {
 			RecruitConcept: "첫 번째 공채",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
@@ -96,21 +96,21 @@ func Test_Accessor_DeleteXXX(t *testing.T) {
 			Number:          "8",
 		},
 	} {
-		if _, err := access.CreateRecruitMember(member); err != nil {
+		if _, err := access.CreateRecruitMember(testCtx, member); err != nil {
 			log.Fatal(err)
 		}
 	}
 
-	_, _ = access.DeleteClub("club-111111111111") // nil, 1
-	_, _ = access.DeleteClubInform("club-111111111111") // nil, 1
-	_, _ = access.DeleteClubMember("club-111111111111", "student-111111111111") // nil, 1
-	_, _ = access.DeleteAllClubMembers("club-111111111111") // nil, 2
-	_, _ = access.DeleteRecruitment("recruitment-111111111111") // nil, 1
-	_, _ = access.DeleteAllRecruitMember("recruitment-111111111111") // nil, 5
+	_, _ = access.DeleteClub(testCtx, "club-111111111111") // nil, 1
+	_, _ = access.DeleteClubInform(testCtx, "club-111111111111") // nil, 1
+	_, _ = access.DeleteClubMember(testCtx, "club-111111111111", "student-111111111111") // nil, 1
+	_, _ = access.DeleteAllClubMembers(testCtx, "club-111111111111") // nil, 2
+	_, _ = access.DeleteRecruitment(testCtx, "recruitment-111111111111") // nil, 1
+	_, _ = access.DeleteAllRecruitMember(testCtx, "recruitment-111111111111") // nil, 5
 
-	_, _ = access.DeleteClub("club-222222222222") // nil, 0
-	_, _ = access.DeleteClubInform("club-222222222222") // nil, 0
-	_, _ = access.DeleteClubMember("club-222222222222", "student-222222222222") // nil, 0
-	_, _ = access.DeleteRecruitment("recruitment-222222222222") // nil, 0
-	_, _ = access.DeleteAllRecruitMember("recruitment-222222222222") // nil, 0
+	_, _ = access.DeleteClub(testCtx, "club-222222222222") // nil, 0
+	_, _ = access.DeleteClubInform(testCtx, "club-222222222222") // nil, 0
+	_, _ = access.DeleteClubMember(testCtx, "club-222222222222", "student-222222222222") // nil, 0
+	_, _ = access.DeleteRecruitment(testCtx, "recruitment-222222222222") // nil, 0
+	_, _ = access.DeleteAllRecruitMember(testCtx, "recruitment-222222222222") // nil, 0
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -21,7 +22,7 @@ func Test_Accessor_GetClubWithClubUUID(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -46,7 +47,7 @@ func Test_Accessor_GetClubWithClubUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := access.GetClubWithClubUUID(test.ClubUUID)
+		result, err := access.GetClubWithClubUUID(testCtx, test.ClubUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result club assertion error (test case: %v)", test)
@@ -65,7 +66,7 @@ func Test_Accessor_GetClubWithLeaderUUID(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -90,7 +91,7 @@ func Test_Accessor_GetClubWithLeaderUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := access.GetClubWithLeaderUUID(test.LeaderUUID)
+		result, err := access.GetClubWithLeaderUUID(testCtx, test.LeaderUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result club assertion error (test case: %v)", test)
@@ -115,7 +116,7 @@ func Test_Accessor_GetCurrentRecruitmentWithClubUUID(t *testing.T) {
 			LeaderUUID: "student-333333333333",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -150,7 +151,7 @@ func Test_Accessor_GetCurrentRecruitmentWithClubUUID(t *testing.T) {
 			RecruitConcept: "두 번째 상시 채용",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
@@ -186,7 +187,7 @@ func Test_Accessor_GetCurrentRecruitmentWithClubUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := access.GetCurrentRecruitmentWithClubUUID(test.ClubUUID)
+		result, err := access.GetCurrentRecruitmentWithClubUUID(testCtx, test.ClubUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result club assertion error (test case: %v)", test)
@@ -211,7 +212,7 @@ func Test_Accessor_GetCurrentRecruitmentWithRecruitmentUUID(t *testing.T) {
 			LeaderUUID: "student-333333333333",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -245,7 +246,7 @@ func Test_Accessor_GetCurrentRecruitmentWithRecruitmentUUID(t *testing.T) {
 			RecruitConcept: "두 번째 상시 채용",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
@@ -281,7 +282,7 @@ func Test_Accessor_GetCurrentRecruitmentWithRecruitmentUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := access.GetCurrentRecruitmentWithRecruitmentUUID(test.RecruitmentUUID)
+		result, err := access.GetCurrentRecruitmentWithRecruitmentUUID(testCtx, test.RecruitmentUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result club assertion error (test case: %v)", test)
@@ -306,7 +307,7 @@ func Test_Accessor_GetClubInformsSortByUpdateTime(t *testing.T) {
 			LeaderUUID: "student-333333333333",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -335,14 +336,14 @@ func Test_Accessor_GetClubInformsSortByUpdateTime(t *testing.T) {
 			LogoURI:  "logo.com/club-333333333333",
 		},
 	} {
-		if _, err := access.CreateClubInform(inform); err != nil {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
 			log.Fatal(err, inform)
 		}
 		time.Sleep(time.Millisecond * 500)
 	}
 
 	time.Sleep(time.Millisecond * 500)
-	if err, _ := access.ModifyClubInform("club-222222222222", &model.ClubInform{
+	if err, _ := access.ModifyClubInform(testCtx, "club-222222222222", 1, &model.ClubInform{
 		ClubConcept:  "DMS의 소속부서 SMS 입니다!",
 		Introduction: "School Management System 서비스를 개발 및 운영합니다",
 		Link:         "facebook.com/DMS-SMS",
@@ -442,7 +443,7 @@ func Test_Accessor_GetClubInformsSortByUpdateTime(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		informs, err := access.GetClubInformsSortByUpdateTime(test.Offset, test.Limit, test.Field, test.Name)
+		informs, err := access.GetClubInformsSortByUpdateTime(testCtx, test.Offset, test.Limit, test.Field, test.Name)
 
 		var exceptedInforms []*model.ClubInform
 		for _, inform := range informs {
@@ -478,7 +479,7 @@ func Test_Accessor_GetCurrentRecruitmentsSortByCreateTime(t *testing.T) {
 			LeaderUUID: "student-555555555555",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -520,7 +521,7 @@ func Test_Accessor_GetCurrentRecruitmentsSortByCreateTime(t *testing.T) {
 			LogoURI:  "logo.com/club-555555555555",
 		},
 	} {
-		if _, err := access.CreateClubInform(inform); err != nil {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
 			log.Fatal(err, inform)
 		}
 	}
@@ -566,17 +567,17 @@ func Test_Accessor_GetCurrentRecruitmentsSortByCreateTime(t *testing.T) {
 			RecruitConcept: "첫 번째 상시 채용",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 		time.Sleep(time.Millisecond * 500)
 	}
 
-	if err, _ := access.DeleteClubInform("club-555555555555"); err != nil {
+	if err, _ := access.DeleteClubInform(testCtx, "club-555555555555"); err != nil {
 		log.Fatal(err)
 	}
 
-	if err, _ := access.DeleteRecruitment("recruitment-666666666666"); err != nil {
+	if err, _ := access.DeleteRecruitment(testCtx, "recruitment-666666666666"); err != nil {
 		log.Fatal(err)
 	}
 
@@ -655,7 +656,7 @@ func Test_Accessor_GetCurrentRecruitmentsSortByCreateTime(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		recruitments, err := access.GetCurrentRecruitmentsSortByCreateTime(test.Offset, test.Limit, test.Field, test.Name)
+		recruitments, err := access.GetCurrentRecruitmentsSortByCreateTime(testCtx, test.Offset, test.Limit, test.Field, test.Name)
 
 		var exceptedRecruitments []*model.ClubRecruitment
 		for _, recruitment := range recruitments {
@@ -679,7 +680,7 @@ func Test_Accessor_GetClubInformWithClubUUID(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -694,7 +695,7 @@ func Test_Accessor_GetClubInformWithClubUUID(t *testing.T) {
 			LogoURI:  "logo.com/club-111111111111",
 		},
 	} {
-		if _, err := access.CreateClubInform(inform); err != nil {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
 			log.Fatal(err, inform)
 		}
 	}
@@ -723,7 +724,7 @@ func Test_Accessor_GetClubInformWithClubUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := access.GetClubInformWithClubUUID(test.ClubUUID)
+		result, err := access.GetClubInformWithClubUUID(testCtx, test.ClubUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result club inform assertion error (test case: %v)", test)
@@ -742,7 +743,7 @@ func Test_Accessor_GetRecruitmentWithRecruitmentUUID(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -763,7 +764,7 @@ func Test_Accessor_GetRecruitmentWithRecruitmentUUID(t *testing.T) {
 			RecruitConcept: "두 번째 공채",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
@@ -791,7 +792,7 @@ func Test_Accessor_GetRecruitmentWithRecruitmentUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := access.GetRecruitmentWithRecruitmentUUID(test.RecruitmentUUID)
+		result, err := access.GetRecruitmentWithRecruitmentUUID(testCtx, test.RecruitmentUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result recruitment assertion error (test case: %v)", test)
@@ -816,7 +817,7 @@ func Test_Accessor_GetClubMembersWithClubUUID(t *testing.T) {
 			LeaderUUID: "student-333333333333",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -836,12 +837,12 @@ func Test_Accessor_GetClubMembersWithClubUUID(t *testing.T) {
 			StudentUUID: "student-333333333333",
 		},
 	} {
-		if _, err := access.CreateClubMember(member); err != nil {
+		if _, err := access.CreateClubMember(testCtx, member); err != nil {
 			log.Fatal(err)
 		}
 	}
 
-	if err, _ := access.DeleteClubMember("club-111111111111", "student-333333333333"); err != nil {
+	if err, _ := access.DeleteClubMember(testCtx, "club-111111111111", "student-333333333333"); err != nil {
 		log.Fatal(err)
 	}
 
@@ -872,7 +873,7 @@ func Test_Accessor_GetClubMembersWithClubUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		resultMembers, err := access.GetClubMembersWithClubUUID(test.ClubUUID)
+		resultMembers, err := access.GetClubMembersWithClubUUID(testCtx, test.ClubUUID)
 
 		var exceptedResult []*model.ClubMember
 		for _, member := range resultMembers {
@@ -896,7 +897,7 @@ func Test_Accessor_GetRecruitMembersWithRecruitmentUUID(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -917,7 +918,7 @@ func Test_Accessor_GetRecruitMembersWithRecruitmentUUID(t *testing.T) {
 			RecruitConcept: "두 번째 공채",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
@@ -950,7 +951,7 @@ func Test_Accessor_GetRecruitMembersWithRecruitmentUUID(t *testing.T) {
 			Number:          "8",
 		},
 	} {
-		if _, err := access.CreateRecruitMember(member); err != nil {
+		if _, err := access.CreateRecruitMember(testCtx, member); err != nil {
 			log.Fatal(err)
 		}
 	}
@@ -1001,7 +1002,7 @@ func Test_Accessor_GetRecruitMembersWithRecruitmentUUID(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		resultMembers, err := access.GetRecruitMembersWithRecruitmentUUID(test.RecruitmentUUID)
+		resultMembers, err := access.GetRecruitMembersWithRecruitmentUUID(testCtx, test.RecruitmentUUID)
 
 		var exceptedResult []*model.RecruitMember
 		for _, member := range resultMembers {
@@ -1037,7 +1038,7 @@ func Test_Accessor_GetAllClubInforms(t *testing.T) {
 			LeaderUUID: "student-555555555555",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -1073,12 +1074,12 @@ func Test_Accessor_GetAllClubInforms(t *testing.T) {
 			LogoURI:  "logo.com/club-444444444444",
 		},
 	} {
-		if _, err := access.CreateClubInform(inform); err != nil {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
 			log.Fatal(err, inform)
 		}
 	}
 
-	if err, _ := access.DeleteClub("club-444444444444"); err != nil {
+	if err, _ := access.DeleteClub(testCtx, "club-444444444444"); err != nil {
 		log.Fatal(err)
 	}
 
@@ -1116,7 +1117,7 @@ func Test_Accessor_GetAllClubInforms(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		resultMembers, err := access.GetAllClubInforms()
+		resultMembers, err := access.GetAllClubInforms(testCtx)
 
 		var exceptedResult []*model.ClubInform
 		for _, member := range resultMembers {
@@ -1149,7 +1150,7 @@ func Test_Accessor_GetAllCurrentRecruitments(t *testing.T) {
 			LeaderUUID: "student-444444444444",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -1191,15 +1192,15 @@ func Test_Accessor_GetAllCurrentRecruitments(t *testing.T) {
 			RecruitConcept: "첫 번째 상시 채용",
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
 
-	if err, _ := access.DeleteClub("club-333333333333"); err != nil {
+	if err, _ := access.DeleteClub(testCtx, "club-333333333333"); err != nil {
 		log.Fatal(err)
 	}
-	if err, _ := access.DeleteRecruitment("recruitment-666666666666"); err != nil {
+	if err, _ := access.DeleteRecruitment(testCtx, "recruitment-666666666666"); err != nil {
 		log.Fatal(err)
 	}
 
@@ -1226,7 +1227,7 @@ func Test_Accessor_GetAllCurrentRecruitments(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		resultMembers, err := access.GetAllCurrentRecruitments()
+		resultMembers, err := access.GetAllCurrentRecruitments(testCtx)
 
 		var exceptedResult []*model.ClubRecruitment
 		for _, member := range resultMembers {
@@ -1237,3 +1238,644 @@ func Test_Accessor_GetAllCurrentRecruitments(t *testing.T) {
 		assert.Equalf(t, test.ExpectResults, exceptedResult, "result recruitments assertion error (test case: %v)", test)
 	}
 }
+
+func Test_Accessor_GetClubsWithClubUUIDs(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		}, {
+			UUID:       "club-222222222222",
+			LeaderUUID: "student-222222222222",
+		}, {
+			UUID:       "club-333333333333",
+			LeaderUUID: "student-333333333333",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	tests := []struct {
+		ClubUUIDs          []string
+		ExpectResults      []*model.Club
+		ExpectError        error
+		ExpectedQueryCount int64
+	} {
+		{
+			ClubUUIDs: []string{"club-111111111111", "club-333333333333", "club-444444444444"},
+			ExpectResults: []*model.Club{
+				{
+					UUID:       "club-111111111111",
+					LeaderUUID: "student-111111111111",
+				}, {
+					UUID:       "club-333333333333",
+					LeaderUUID: "student-333333333333",
+				},
+			},
+			ExpectError:        nil,
+			ExpectedQueryCount: 1,
+		}, {
+			ClubUUIDs:          []string{"club-444444444444", "club-555555555555"},
+			ExpectError:        gorm.ErrRecordNotFound,
+			ExpectedQueryCount: 1,
+		}, {
+			ClubUUIDs:          []string{},
+			ExpectError:        gorm.ErrRecordNotFound,
+			ExpectedQueryCount: 0,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		resultClubs, err := access.GetClubsWithClubUUIDs(testCtx, test.ClubUUIDs)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		var exceptedResult []*model.Club
+		for _, club := range resultClubs {
+			exceptedResult = append(exceptedResult, club.ExceptGormModel())
+		}
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.ElementsMatchf(t, test.ExpectResults, exceptedResult, "result clubs assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedQueryCount, executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
+
+func Test_Accessor_GetClubInformsWithClubUUIDs(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		}, {
+			UUID:       "club-222222222222",
+			LeaderUUID: "student-222222222222",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	for _, inform := range []*model.ClubInform{
+		{
+			ClubUUID: "club-111111111111",
+			Name:     "DMS",
+			Field:    "SW 개발",
+			Location: "2-1반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-111111111111",
+		}, {
+			ClubUUID: "club-222222222222",
+			Name:     "SMS",
+			Field:    "SW 개발",
+			Location: "2-2반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-222222222222",
+		},
+	} {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
+			log.Fatal(err, inform)
+		}
+	}
+
+	tests := []struct {
+		ClubUUIDs          []string
+		ExpectResults      []*model.ClubInform
+		ExpectError        error
+		ExpectedQueryCount int64
+	} {
+		{
+			ClubUUIDs: []string{"club-111111111111", "club-222222222222"},
+			ExpectResults: []*model.ClubInform{
+				{
+					ClubUUID: "club-111111111111",
+					Name:     "DMS",
+					Field:    "SW 개발",
+					Location: "2-1반 교실",
+					Floor:    "3",
+					LogoURI:  "logo.com/club-111111111111",
+				}, {
+					ClubUUID: "club-222222222222",
+					Name:     "SMS",
+					Field:    "SW 개발",
+					Location: "2-2반 교실",
+					Floor:    "3",
+					LogoURI:  "logo.com/club-222222222222",
+				},
+			},
+			ExpectError:        nil,
+			ExpectedQueryCount: 1,
+		}, {
+			ClubUUIDs:          []string{"club-333333333333"},
+			ExpectError:        gorm.ErrRecordNotFound,
+			ExpectedQueryCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		resultInforms, err := access.GetClubInformsWithClubUUIDs(testCtx, test.ClubUUIDs)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		var exceptedResult []*model.ClubInform
+		for _, inform := range resultInforms {
+			exceptedResult = append(exceptedResult, inform.ExceptGormModel())
+		}
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.ElementsMatchf(t, test.ExpectResults, exceptedResult, "result club informs assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedQueryCount, executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
+
+func Test_Accessor_GetClubMembersWithClubUUIDs(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		}, {
+			UUID:       "club-222222222222",
+			LeaderUUID: "student-222222222222",
+		}, {
+			UUID:       "club-333333333333",
+			LeaderUUID: "student-333333333333",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	for _, member := range []*model.ClubMember{
+		{
+			ClubUUID:    "club-111111111111",
+			StudentUUID: "student-111111111111",
+		}, {
+			ClubUUID:    "club-111111111111",
+			StudentUUID: "student-222222222222",
+		}, {
+			ClubUUID:    "club-222222222222",
+			StudentUUID: "student-333333333333",
+		}, {
+			ClubUUID:    "club-333333333333",
+			StudentUUID: "student-444444444444",
+		},
+	} {
+		if _, err := access.CreateClubMember(testCtx, member); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err, _ := access.DeleteClubMember(testCtx, "club-111111111111", "student-222222222222"); err != nil {
+		log.Fatal(err)
+	}
+
+	tests := []struct {
+		ClubUUIDs          []string
+		ExpectResults      []*model.ClubMember
+		ExpectError        error
+		ExpectedQueryCount int64
+	} {
+		{
+			ClubUUIDs: []string{"club-111111111111", "club-222222222222"},
+			ExpectResults: []*model.ClubMember{
+				{
+					ClubUUID:    "club-111111111111",
+					StudentUUID: "student-111111111111",
+				}, {
+					ClubUUID:    "club-222222222222",
+					StudentUUID: "student-333333333333",
+				},
+			},
+			ExpectError:        nil,
+			ExpectedQueryCount: 1,
+		}, {
+			ClubUUIDs:          []string{"club-444444444444"},
+			ExpectError:        gorm.ErrRecordNotFound,
+			ExpectedQueryCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		resultMembers, err := access.GetClubMembersWithClubUUIDs(testCtx, test.ClubUUIDs)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		var exceptedResult []*model.ClubMember
+		for _, member := range resultMembers {
+			exceptedResult = append(exceptedResult, member.ExceptGormModel())
+		}
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.ElementsMatchf(t, test.ExpectResults, exceptedResult, "result members assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedQueryCount, executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
+
+func Test_Accessor_GetRecruitMembersWithRecruitmentUUIDs(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	for _, recruitment := range []*model.ClubRecruitment{
+		{
+			UUID:           "recruitment-111111111111",
+			ClubUUID:       "club-111111111111",
+			RecruitConcept: "첫 번째 공채",
+		}, {
+			UUID:           "recruitment-222222222222",
+			ClubUUID:       "club-111111111111",
+			RecruitConcept: "두 번째 공채",
+		},
+	} {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
+			log.Fatal(err, recruitment)
+		}
+	}
+
+	for _, member := range []*model.RecruitMember{
+		{
+			RecruitmentUUID: "recruitment-111111111111",
+			Grade:           "2",
+			Field:           "서버 개발자",
+			Number:          "2",
+		}, {
+			RecruitmentUUID: "recruitment-222222222222",
+			Grade:           "1",
+			Field:           "웹 프론트 개발자",
+			Number:          "1",
+		},
+	} {
+		if _, err := access.CreateRecruitMember(testCtx, member); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		RecruitmentUUIDs   []string
+		ExpectResults      []*model.RecruitMember
+		ExpectError        error
+		ExpectedQueryCount int64
+	} {
+		{
+			RecruitmentUUIDs: []string{"recruitment-111111111111", "recruitment-222222222222", "recruitment-333333333333"},
+			ExpectResults: []*model.RecruitMember{
+				{
+					RecruitmentUUID: "recruitment-111111111111",
+					Grade:           "2",
+					Field:           "서버 개발자",
+					Number:          "2",
+				}, {
+					RecruitmentUUID: "recruitment-222222222222",
+					Grade:           "1",
+					Field:           "웹 프론트 개발자",
+					Number:          "1",
+				},
+			},
+			ExpectError:        nil,
+			ExpectedQueryCount: 1,
+		}, {
+			RecruitmentUUIDs:   []string{"recruitment-333333333333"},
+			ExpectError:        gorm.ErrRecordNotFound,
+			ExpectedQueryCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		resultMembers, err := access.GetRecruitMembersWithRecruitmentUUIDs(testCtx, test.RecruitmentUUIDs)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		var exceptedResult []*model.RecruitMember
+		for _, member := range resultMembers {
+			exceptedResult = append(exceptedResult, member.ExceptGormModel())
+		}
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.ElementsMatchf(t, test.ExpectResults, exceptedResult, "result members assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedQueryCount, executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
+
+func Test_Accessor_GetClubInformsCount(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		}, {
+			UUID:       "club-222222222222",
+			LeaderUUID: "student-222222222222",
+		}, {
+			UUID:       "club-333333333333",
+			LeaderUUID: "student-333333333333",
+		}, {
+			UUID:       "club-444444444444",
+			LeaderUUID: "student-444444444444",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	for _, inform := range []*model.ClubInform{
+		{
+			ClubUUID: "club-111111111111",
+			Name:     "DMS",
+			Field:    "SW 개발",
+			Location: "2-1반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-111111111111",
+		}, {
+			ClubUUID: "club-222222222222",
+			Name:     "SMS",
+			Field:    "SW 개발",
+			Location: "2-2반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-222222222222",
+		}, {
+			ClubUUID: "club-333333333333",
+			Name:     "ESC",
+			Field:    "임베디드 SW 개발",
+			Location: "세미나실 어딘가",
+			Floor:    "2",
+			LogoURI:  "logo.com/club-333333333333",
+		}, {
+			ClubUUID: "club-444444444444",
+			Name:     "PMS",
+			Field:    "SW 개발",
+			Location: "2-3반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-444444444444",
+		},
+	} {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
+			log.Fatal(err, inform)
+		}
+	}
+
+	if err, _ := access.DeleteClub(testCtx, "club-444444444444"); err != nil {
+		log.Fatal(err)
+	}
+
+	tests := []struct {
+		Field, Name, Floor string
+		ExpectCount        int64
+		ExpectError        error
+	} {
+		{
+			ExpectCount: 3,
+			ExpectError: nil,
+		}, {
+			Field:       "SW",
+			Name:        "MS",
+			ExpectCount: 2,
+			ExpectError: nil,
+		}, {
+			Floor:       "2",
+			ExpectCount: 1,
+			ExpectError: nil,
+		}, {
+			Name:        "PMS",
+			ExpectCount: 0,
+			ExpectError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		count, err := access.GetClubInformsCount(testCtx, test.Field, test.Name, test.Floor)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectCount, count, "result count assertion error (test case: %v)", test)
+		assert.Equalf(t, int64(1), executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
+
+func Test_Accessor_GetCurrentRecruitmentsCount(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		}, {
+			UUID:       "club-222222222222",
+			LeaderUUID: "student-222222222222",
+		}, {
+			UUID:       "club-333333333333",
+			LeaderUUID: "student-333333333333",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	for _, inform := range []*model.ClubInform{
+		{
+			ClubUUID: "club-111111111111",
+			Name:     "DMS",
+			Field:    "SW 개발",
+			Location: "2-1반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-111111111111",
+		}, {
+			ClubUUID: "club-222222222222",
+			Name:     "ESC",
+			Field:    "임베디드 SW 개발",
+			Location: "세미나실 어딘가",
+			Floor:    "2",
+			LogoURI:  "logo.com/club-222222222222",
+		}, {
+			ClubUUID: "club-333333333333",
+			Name:     "SMS",
+			Field:    "SW 개발",
+			Location: "2-2반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-333333333333",
+		},
+	} {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
+			log.Fatal(err, inform)
+		}
+	}
+
+	for _, recruitment := range []*model.ClubRecruitment{
+		{ // 종료된 채용
+			UUID:           "recruitment-111111111111",
+			ClubUUID:       "club-111111111111",
+			RecruitConcept: "첫 번째 공채",
+			StartPeriod:    model.StartPeriod(time.Date(2020, time.Month(9), 17, 0, 0, 0, 0, time.UTC)),
+			EndPeriod:      model.EndPeriod(time.Date(2020, time.Month(9), 24, 0, 0, 0, 0, time.UTC)),
+		}, { // 상시 채용
+			UUID:           "recruitment-222222222222",
+			ClubUUID:       "club-111111111111",
+			RecruitConcept: "두 번째 상시 채용",
+		}, { // 상시 채용
+			UUID:           "recruitment-333333333333",
+			ClubUUID:       "club-222222222222",
+			RecruitConcept: "첫 번째 상시 채용",
+		}, { // 상시 채용
+			UUID:           "recruitment-444444444444",
+			ClubUUID:       "club-333333333333",
+			RecruitConcept: "첫 번째 상시 채용",
+		},
+	} {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
+			log.Fatal(err, recruitment)
+		}
+	}
+
+	if err, _ := access.DeleteClub(testCtx, "club-333333333333"); err != nil {
+		log.Fatal(err)
+	}
+
+	tests := []struct {
+		Field, Name string
+		ExpectCount int64
+		ExpectError error
+	} {
+		{
+			ExpectCount: 2,
+			ExpectError: nil,
+		}, {
+			Field:       "임베디드",
+			ExpectCount: 1,
+			ExpectError: nil,
+		}, {
+			Name:        "SMS",
+			ExpectCount: 0,
+			ExpectError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		count, err := access.GetCurrentRecruitmentsCount(testCtx, test.Field, test.Name)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectCount, count, "result count assertion error (test case: %v)", test)
+		assert.Equalf(t, int64(1), executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
+
+func Test_Accessor_GetDistinctClubFields(t *testing.T) {
+	access := manager.BeginTx()
+	defer func() {
+		access.Rollback()
+	}()
+
+	for _, club := range []*model.Club{
+		{
+			UUID:       "club-111111111111",
+			LeaderUUID: "student-111111111111",
+		}, {
+			UUID:       "club-222222222222",
+			LeaderUUID: "student-222222222222",
+		}, {
+			UUID:       "club-333333333333",
+			LeaderUUID: "student-333333333333",
+		},
+	} {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
+			log.Fatal(err, club)
+		}
+	}
+
+	for _, inform := range []*model.ClubInform{
+		{
+			ClubUUID: "club-111111111111",
+			Name:     "DMS",
+			Field:    "SW 개발",
+			Location: "2-1반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-111111111111",
+		}, {
+			ClubUUID: "club-222222222222",
+			Name:     "SMS",
+			Field:    "SW 개발",
+			Location: "2-2반 교실",
+			Floor:    "3",
+			LogoURI:  "logo.com/club-222222222222",
+		}, {
+			ClubUUID: "club-333333333333",
+			Name:     "ESC",
+			Field:    "임베디드 SW 개발",
+			Location: "세미나실 어딘가",
+			Floor:    "2",
+			LogoURI:  "logo.com/club-333333333333",
+		},
+	} {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
+			log.Fatal(err, inform)
+		}
+	}
+
+	tests := []struct {
+		Name, Floor   string
+		ExpectResults []string
+		ExpectError   error
+	} {
+		{
+			ExpectResults: []string{"SW 개발", "임베디드 SW 개발"},
+			ExpectError:   nil,
+		}, {
+			Floor:         "3",
+			ExpectResults: []string{"SW 개발"},
+			ExpectError:   nil,
+		}, {
+			Name:        "PMS",
+			ExpectError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		startQueryCount := atomic.LoadInt64(&queryCount)
+		fields, err := access.GetDistinctClubFields(testCtx, test.Name, test.Floor)
+		executedQueryCount := atomic.LoadInt64(&queryCount) - startQueryCount
+
+		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
+		assert.ElementsMatchf(t, test.ExpectResults, fields, "result fields assertion error (test case: %v)", test)
+		assert.Equalf(t, int64(1), executedQueryCount, "query count assertion error (test case: %v)", test)
+	}
+}
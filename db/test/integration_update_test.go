@@ -27,7 +27,7 @@ func Test_Accessor_ChangeClubLeader(t *testing.T) {
 			LeaderUUID: "student-222222222222",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -62,7 +62,7 @@ func Test_Accessor_ChangeClubLeader(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err, rowAffected := access.ChangeClubLeader(test.ClubUUID, test.NewLeaderUUID)
+		err, rowAffected := access.ChangeClubLeader(testCtx, test.ClubUUID, test.NewLeaderUUID)
 
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
 			err = mysqlerr.ExceptReferenceInformFrom(mysqlErr)
@@ -93,7 +93,7 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			LeaderUUID: "student-222222222222",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -115,13 +115,14 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			LogoURI:  "logo.com/club-222222222222",
 		},
 	} {
-		if _, err := access.CreateClubInform(inform); err != nil {
+		if _, err := access.CreateClubInform(testCtx, inform); err != nil {
 			log.Fatal(err, inform)
 		}
 	}
 
 	tests := []struct {
 		ClubUUID       string
+		Version        uint
 		RevisionInform *model.ClubInform
 		IsInvalid      bool
 		ExpectError    error
@@ -129,6 +130,7 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 	} {
 		{ // success case
 			ClubUUID: "club-111111111111",
+			Version:  1,
 			RevisionInform: &model.ClubInform{
 				ClubConcept:  "DMS 개발 및 운영",
 				Introduction: "우리 DMS 동아리 완전 좋아요~",
@@ -138,6 +140,7 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			ExpectRows:  1,
 		}, { // name duplicate error
 			ClubUUID: "club-111111111111",
+			Version:  2,
 			RevisionInform: &model.ClubInform{
 				Name: "SMS",
 			},
@@ -145,6 +148,7 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			ExpectRows:  0,
 		}, { // name duplicate error
 			ClubUUID: "club-111111111111",
+			Version:  2,
 			RevisionInform: &model.ClubInform{
 				Location: "2-2반 교실",
 			},
@@ -152,6 +156,7 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			ExpectRows:  0,
 		}, { // floor invalid
 			ClubUUID: "club-111111111111",
+			Version:  2,
 			RevisionInform: &model.ClubInform{
 				Floor: "7",
 			},
@@ -159,6 +164,7 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			ExpectRows: 0,
 		}, { // name invalid
 			ClubUUID: "club-111111111111",
+			Version:  2,
 			RevisionInform: &model.ClubInform{
 				Name: "이거 30글자 넘음 30글자 넘으면 유효성 검사 부분에서 오류가 나야만함 그래야만함 나겠죠?",
 			},
@@ -166,16 +172,41 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 			ExpectRows: 0,
 		}, {
 			ClubUUID: "club-111111111111",
+			Version:  2,
 			RevisionInform: &model.ClubInform{
 				ClubUUID: "club-123412341234",
 			},
 			ExpectError: errors.ClubUUIDCannotBeChanged,
 			ExpectRows:  0,
+		}, { // version conflict (modified by other request after read)
+			ClubUUID: "club-111111111111",
+			Version:  1,
+			RevisionInform: &model.ClubInform{
+				ClubConcept: "DMS 개발 및 운영 (수정 충돌)",
+			},
+			ExpectError: errors.VersionConflict,
+			ExpectRows:  0,
+		}, { // club inform not exist
+			ClubUUID: "club-333333333333",
+			Version:  1,
+			RevisionInform: &model.ClubInform{
+				ClubConcept: "존재하지 않는 동아리",
+			},
+			ExpectError: nil,
+			ExpectRows:  0,
+		}, { // version not checked (request without version), but version is increased
+			ClubUUID: "club-222222222222",
+			Version:  0,
+			RevisionInform: &model.ClubInform{
+				ClubConcept: "SMS 개발 및 운영",
+			},
+			ExpectError: nil,
+			ExpectRows:  1,
 		},
 	}
 
 	for _, test := range tests {
-		err, rowAffected := access.ModifyClubInform(test.ClubUUID, test.RevisionInform)
+		err, rowAffected := access.ModifyClubInform(testCtx, test.ClubUUID, test.Version, test.RevisionInform)
 
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
 			err = mysqlerr.ExceptReferenceInformFrom(mysqlErr)
@@ -191,12 +222,14 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 	}
 
 	confirmTests := []struct {
-		ClubUUID   string
-		ExpectResult *model.ClubInform
-		ExpectError  error
+		ClubUUID      string
+		ExpectResult  *model.ClubInform
+		ExpectVersion uint
+		ExpectError   error
 	}{
 		{
-			ClubUUID: "club-111111111111",
+			ClubUUID:      "club-111111111111",
+			ExpectVersion: 2,
 			ExpectResult: &model.ClubInform{
 				ClubUUID:     "club-111111111111",
 				Name:         "DMS",
@@ -209,14 +242,28 @@ func Test_Accessor_ModifyClubInform(t *testing.T) {
 				Link:         "facebook.com/DSM-DMS",
 			},
 			ExpectError: nil,
+		}, {
+			ClubUUID:      "club-222222222222",
+			ExpectVersion: 2,
+			ExpectResult: &model.ClubInform{
+				ClubUUID:    "club-222222222222",
+				Name:        "SMS",
+				Field:       "SW 개발",
+				Location:    "2-2반 교실",
+				Floor:       "3",
+				LogoURI:     "logo.com/club-222222222222",
+				ClubConcept: "SMS 개발 및 운영",
+			},
+			ExpectError: nil,
 		},
 	}
 
 	for _, test := range confirmTests {
-		result, err := access.GetClubInformWithClubUUID(test.ClubUUID)
+		result, err := access.GetClubInformWithClubUUID(testCtx, test.ClubUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result club inform assertion error (test case: %v)", test)
+		assert.Equalf(t, model.Version(test.ExpectVersion), result.Version, "result version assertion error (test case: %v)", test)
 	}
 }
 
@@ -232,7 +279,7 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 			LeaderUUID: "student-111111111111",
 		},
 	} {
-		if _, err := access.CreateClub(club); err != nil {
+		if _, err := access.CreateClub(testCtx, club); err != nil {
 			log.Fatal(err, club)
 		}
 	}
@@ -250,13 +297,14 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 			EndPeriod:      model.EndPeriod(endTime),
 		},
 	} {
-		if _, err := access.CreateRecruitment(recruitment); err != nil {
+		if _, err := access.CreateRecruitment(testCtx, recruitment); err != nil {
 			log.Fatal(err, recruitment)
 		}
 	}
 
 	tests := []struct {
 		RecruitmentUUID string
+		Version         uint
 		RevisionRecruit *model.ClubRecruitment
 		IsInvalid       bool
 		ExpectError     error
@@ -264,6 +312,7 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 	} {
 		{ // success case
 			RecruitmentUUID: "recruitment-111111111111",
+			Version:         1,
 			RevisionRecruit: &model.ClubRecruitment{
 				RecruitConcept: "응 상시 채용으로 바꿔",
 				StartPeriod:    model.StartPeriod(model.ClubRecruitmentInstance.StartPeriod.NullReplaceValue()),
@@ -273,6 +322,7 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 			ExpectRows:  1,
 		}, { // floor invalid
 			RecruitmentUUID: "recruitment-111111111111",
+			Version:         2,
 			RevisionRecruit: &model.ClubRecruitment{
 				RecruitConcept: "이것도 40자가 넘도록 만들어야되는데 곧 있으면 40자가 될 것 같다 만약 이게 40자가 된다면 유효성 오류가 발생해야한다",
 			},
@@ -280,6 +330,7 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 			ExpectRows: 0,
 		}, {
 			RecruitmentUUID: "recruitment-111111111111",
+			Version:         2,
 			RevisionRecruit: &model.ClubRecruitment{
 				ClubUUID: "club-123412341234",
 			},
@@ -287,16 +338,33 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 			ExpectRows:  0,
 		}, {
 			RecruitmentUUID: "recruitment-111111111111",
+			Version:         2,
 			RevisionRecruit: &model.ClubRecruitment{
 				UUID: "recruitment-123412341234",
 			},
 			ExpectError: errors.RecruitmentUUIDCannotBeChanged,
 			ExpectRows:  0,
+		}, { // version conflict (modified by other request after read)
+			RecruitmentUUID: "recruitment-111111111111",
+			Version:         1,
+			RevisionRecruit: &model.ClubRecruitment{
+				RecruitConcept: "수정 충돌이 발생해야 하는 채용",
+			},
+			ExpectError: errors.VersionConflict,
+			ExpectRows:  0,
+		}, { // version not checked (request without version), but version is increased
+			RecruitmentUUID: "recruitment-111111111111",
+			Version:         0,
+			RevisionRecruit: &model.ClubRecruitment{
+				RecruitConcept: "상시 채용",
+			},
+			ExpectError: nil,
+			ExpectRows:  1,
 		},
 	}
 
 	for _, test := range tests {
-		err, rowAffected := access.ModifyRecruitment(test.RecruitmentUUID, test.RevisionRecruit)
+		err, rowAffected := access.ModifyRecruitment(testCtx, test.RecruitmentUUID, test.Version, test.RevisionRecruit)
 
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
 			err = mysqlerr.ExceptReferenceInformFrom(mysqlErr)
@@ -314,23 +382,26 @@ func Test_Accessor_ModifyRecruitment(t *testing.T) {
 	confirmTests := []struct {
 		RecruitmentUUID string
 		ExpectResult    *model.ClubRecruitment
+		ExpectVersion   uint
 		ExpectError     error
 	} {
 		{
 			RecruitmentUUID: "recruitment-111111111111",
+			ExpectVersion:   3,
 			ExpectResult: &model.ClubRecruitment{
 				UUID:           "recruitment-111111111111",
 				ClubUUID:       "club-111111111111",
-				RecruitConcept: "응 상시 채용으로 바꿔",
+				RecruitConcept: "상시 채용",
 			},
 			ExpectError: nil,
 		},
 	}
 
 	for _, test := range confirmTests {
-		result, err := access.GetRecruitmentWithRecruitmentUUID(test.RecruitmentUUID)
+		result, err := access.GetRecruitmentWithRecruitmentUUID(testCtx, test.RecruitmentUUID)
 
 		assert.Equalf(t, test.ExpectError, err, "error assertion error (test case: %v)", test)
 		assert.Equalf(t, test.ExpectResult, result.ExceptGormModel(), "result recruitment assertion error (test case: %v)", test)
+		assert.Equalf(t, model.Version(test.ExpectVersion), result.Version, "result version assertion error (test case: %v)", test)
 	}
 }
@@ -0,0 +1,82 @@
+// tx_retry.go is file to declare running unit of work in transaction with AccessorManage
+// whole transaction is run again with jittered backoff if it fails with retryable error like deadlock & lock wait timeout
+
+package db
+
+import (
+	"club/tool/mysqlerr"
+	"context"
+	log "github.com/micro/go-micro/v2/logger"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultTxMaxAttempts  = 3
+	defaultTxRetryBackoff = time.Millisecond * 50
+)
+
+type txRetry struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// set max count of running transaction in RunInTx, including first one
+func TxMaxAttempts(attempts int) AccessorManageFieldSetter {
+	return func(atm *AccessorManage) {
+		atm.txRetry.maxAttempts = attempts
+	}
+}
+
+// set base backoff before running transaction again, it is doubled in each retry & jittered
+func TxRetryBackoff(backoff time.Duration) AccessorManageFieldSetter {
+	return func(atm *AccessorManage) {
+		atm.txRetry.backoff = backoff
+	}
+}
+
+// run fn in transaction, which is committed if fn returns nil & rolled back if not
+// fn may be called more than once, so it must not have side effect out of transaction (ex. response, file)
+// retry stops if ctx is done while waiting backoff, and error of last transaction is returned
+func (atm AccessorManage) RunInTx(ctx context.Context, fn func(access Accessor) error) (err error) {
+	for attempt := 1; ; attempt++ {
+		err = atm.runTxOnce(fn)
+		if err == nil || !mysqlerr.IsRetryable(err) || attempt >= atm.txRetry.maxAttempts {
+			return
+		}
+
+		backoff := atm.txRetry.backoffOf(attempt)
+		log.Infof("transaction failed with retryable error, it will be run again after %v (attempt: %d, err: %v)", backoff, attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (atm AccessorManage) runTxOnce(fn func(access Accessor) error) (err error) {
+	access := atm.BeginTx()
+	defer func() {
+		if r := recover(); r != nil {
+			access.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err = fn(access); err != nil {
+		access.Rollback()
+		return
+	}
+	return access.Commit().Error
+}
+
+// returns random duration between half & whole of base backoff doubled by count of previous attempts
+func (r txRetry) backoffOf(attempt int) time.Duration {
+	backoff := r.backoff << uint(attempt-1)
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
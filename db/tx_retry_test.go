@@ -0,0 +1,93 @@
+package db_test
+
+import (
+	"club/db"
+	"club/db/access"
+	"context"
+	"errors"
+	mysqlcode "github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func Test_AccessorManage_RunInTx(t *testing.T) {
+	deadlockErr := &mysql.MySQLError{Number: mysqlcode.ER_LOCK_DEADLOCK, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+	lockWaitTimeoutErr := &mysql.MySQLError{Number: mysqlcode.ER_LOCK_WAIT_TIMEOUT, Message: "Lock wait timeout exceeded; try restarting transaction"}
+	duplicateErr := &mysql.MySQLError{Number: mysqlcode.ER_DUP_ENTRY, Message: "Duplicate entry 'DMS' for key 'name'"}
+
+	tests := []struct {
+		Errors           []error
+		ExpectedAttempts int
+		ExpectedError    error
+	}{
+		{ // success in first attempt
+			Errors:           []error{nil},
+			ExpectedAttempts: 1,
+		}, { // deadlock & lock wait timeout -> retried until success
+			Errors:           []error{deadlockErr, lockWaitTimeoutErr, nil},
+			ExpectedAttempts: 3,
+		}, { // retryable error in all attempts -> last error returned
+			Errors:           []error{deadlockErr, deadlockErr, deadlockErr, nil},
+			ExpectedAttempts: 3,
+			ExpectedError:    deadlockErr,
+		}, { // not retryable MySQL error -> not retried
+			Errors:           []error{duplicateErr, nil},
+			ExpectedAttempts: 1,
+			ExpectedError:    duplicateErr,
+		}, { // unexpected error -> not retried
+			Errors:           []error{errors.New("unexpected error"), nil},
+			ExpectedAttempts: 1,
+			ExpectedError:    errors.New("unexpected error"),
+		},
+	}
+
+	for _, testCase := range tests {
+		newMock := new(mock.Mock)
+		newMock.On("BeginTx").Return()
+		newMock.On("Commit").Return(&gorm.DB{})
+		newMock.On("Rollback").Return(&gorm.DB{})
+
+		manager, err := db.NewAccessorManage(access.Mock(newMock), db.TxMaxAttempts(3), db.TxRetryBackoff(time.Millisecond))
+		assert.Nil(t, err)
+
+		attempts := 0
+		err = manager.RunInTx(context.Background(), func(access db.Accessor) error {
+			attempts++
+			return testCase.Errors[attempts-1]
+		})
+
+		assert.Equalf(t, testCase.ExpectedError, err, "error assertion error (test case: %v)", testCase)
+		assert.Equalf(t, testCase.ExpectedAttempts, attempts, "attempts assertion error (test case: %v)", testCase)
+		newMock.AssertNumberOfCalls(t, "BeginTx", testCase.ExpectedAttempts)
+		if testCase.ExpectedError == nil {
+			newMock.AssertNumberOfCalls(t, "Commit", 1)
+			newMock.AssertNumberOfCalls(t, "Rollback", testCase.ExpectedAttempts-1)
+		} else {
+			newMock.AssertNotCalled(t, "Commit")
+			newMock.AssertNumberOfCalls(t, "Rollback", testCase.ExpectedAttempts)
+		}
+	}
+}
+
+func Test_AccessorManage_RunInTx_ContextDone(t *testing.T) {
+	newMock := new(mock.Mock)
+	newMock.On("BeginTx").Return()
+	newMock.On("Rollback").Return(&gorm.DB{})
+
+	manager, err := db.NewAccessorManage(access.Mock(newMock), db.TxMaxAttempts(5), db.TxRetryBackoff(time.Hour))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	deadlockErr := &mysql.MySQLError{Number: mysqlcode.ER_LOCK_DEADLOCK, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+	err = manager.RunInTx(ctx, func(access db.Accessor) error { return deadlockErr })
+
+	// retry stops while waiting backoff, and error of last transaction is returned
+	assert.Equal(t, deadlockErr, err)
+	newMock.AssertNumberOfCalls(t, "BeginTx", 1)
+}
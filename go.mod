@@ -18,7 +18,9 @@ require (
 	github.com/google/uuid v1.1.1
 	github.com/hashicorp/consul/api v1.7.0
 	github.com/jinzhu/gorm v1.9.16
+	github.com/mattn/go-sqlite3 v1.14.0
 	github.com/mervick/aes-everywhere/go/aes256 v0.0.0-20201120204945-cd607c782ed1
+	github.com/micro/cli/v2 v2.1.2
 	github.com/micro/go-micro/v2 v2.9.1
 	github.com/opentracing/opentracing-go v1.1.0
 	github.com/pkg/errors v0.9.1
@@ -27,5 +29,6 @@ require (
 	github.com/uber/jaeger-lib v2.4.0+incompatible // indirect
 	google.golang.org/protobuf v1.22.0
 	gorm.io/driver/mysql v1.0.1
+	gorm.io/driver/sqlite v1.1.1
 	gorm.io/gorm v1.20.1
 )
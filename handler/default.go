@@ -1,19 +1,22 @@
 package handler
 
 import (
+	"club/config"
 	"club/consul"
 	"club/db"
 	authproto "club/proto/golang/auth"
-	"github.com/aws/aws-sdk-go/aws/session"
+	"club/storage"
 	"github.com/opentracing/opentracing-go"
 )
 
 type _default struct {
-	accessManage db.AccessorManage
-	tracer       opentracing.Tracer
-	awsSession   *session.Session
-	consulAgent  consul.Agent
-	authStudent  authproto.AuthStudentService
+	accessManage  db.AccessorManage
+	tracer        opentracing.Tracer
+	logoStorage   storage.Storage
+	consulAgent   consul.Agent
+	authStudent   authproto.AuthStudentService
+	runtimeConfig config.RuntimeGetter
+	featureFlags  config.FlagGetter
 }
 
 type FieldSetter func(*_default)
@@ -24,6 +27,8 @@ func Default(setters ...FieldSetter) *_default {
 
 func newDefault(setters ...FieldSetter) (h *_default) {
 	h = new(_default)
+	h.runtimeConfig = config.Runtime()
+	h.featureFlags = config.Flags()
 	for _, setter := range setters {
 		setter(h)
 	}
@@ -42,9 +47,10 @@ func Tracer(t opentracing.Tracer) FieldSetter {
 	}
 }
 
-func AWSSession(s *session.Session) FieldSetter {
+// set storage for club logo, logo is not stored if not set
+func LogoStorage(s storage.Storage) FieldSetter {
 	return func(h *_default) {
-		h.awsSession = s
+		h.logoStorage = s
 	}
 }
 
@@ -59,3 +65,17 @@ func AuthStudent(as authproto.AuthStudentService) FieldSetter {
 		h.authStudent = as
 	}
 }
+
+// set runtime configuration reloaded from consul KV, default runtime document is used if not set
+func RuntimeConfig(rc config.RuntimeGetter) FieldSetter {
+	return func(h *_default) {
+		h.runtimeConfig = rc
+	}
+}
+
+// set feature flags loaded from consul KV, all flags are off if not set
+func FeatureFlags(ff config.FlagGetter) FieldSetter {
+	return func(h *_default) {
+		h.featureFlags = ff
+	}
+}
@@ -4,12 +4,47 @@
 package handler
 
 import (
-	"github.com/aws/aws-sdk-go/service/sqs"
+	"club/consul"
+	"club/subscriber"
+	"encoding/json"
+	"errors"
+	"fmt"
 	log "github.com/micro/go-micro/v2/logger"
 )
 
-func (d *_default) ChangeConsulNodes(message *sqs.Message) (err error) {
-	err = d.consulAgent.ChangeAllServiceNodes()
-	log.Infof("change all service nodes!, err: %v", err)
+// event type of message published when service nodes registered in consul are changed
+const EventConsulNodesChanged = "consul.nodes.changed"
+
+type consulNodesChangedPayload struct {
+	Services []string `json:"services"`
+}
+
+// handle EventConsulNodesChanged event, refresh all service nodes if services in payload is empty
+func (d *_default) ChangeConsulNodes(event *subscriber.Envelope) (err error) {
+	if event.Version != 1 {
+		err = subscriber.MalformedPayload(fmt.Sprintf("unsupported event version, version: %d", event.Version))
+		return
+	}
+
+	payload := new(consulNodesChangedPayload)
+	if len(event.Payload) != 0 {
+		if unmarshalErr := json.Unmarshal(event.Payload, payload); unmarshalErr != nil {
+			err = subscriber.MalformedPayload(unmarshalErr.Error())
+			return
+		}
+	}
+
+	if len(payload.Services) == 0 {
+		err = d.consulAgent.ChangeAllServiceNodes()
+		log.Infof("change all service nodes!, err: %v", err)
+		return
+	}
+
+	for _, service := range payload.Services {
+		if changeErr := d.consulAgent.ChangeServiceNodes(consul.ServiceName(service)); changeErr != nil {
+			err = errors.New(fmt.Sprintf("unable to change service nodes, service: %s, err: %v", service, changeErr))
+		}
+	}
+	log.Infof("change service nodes!, services: %v, err: %v", payload.Services, err)
 	return
 }
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"club/consul"
+	"club/subscriber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func Test_default_ChangeConsulNodes(t *testing.T) {
+	const queue = "change-consul-club"
+
+	tests := []struct {
+		Body            string
+		ExpectedMethods map[string][]interface{}
+		ExpectedPoison  bool
+	}{
+		{ // success case (refresh all service nodes)
+			Body:            `{"type": "consul.nodes.changed", "version": 1}`,
+			ExpectedMethods: map[string][]interface{}{"ChangeAllServiceNodes": {}},
+		}, { // success case (refresh specific service nodes)
+			Body:            `{"type": "consul.nodes.changed", "version": 1, "payload": {"services": ["DMS.SMS.v1.service.auth"]}}`,
+			ExpectedMethods: map[string][]interface{}{"ChangeServiceNodes": {consul.ServiceName("DMS.SMS.v1.service.auth")}},
+		}, { // unsupported version -> poison message
+			Body:           `{"type": "consul.nodes.changed", "version": 2}`,
+			ExpectedPoison: true,
+		}, { // malformed payload -> poison message
+			Body:           `{"type": "consul.nodes.changed", "version": 1, "payload": {"services": "auth"}}`,
+			ExpectedPoison: true,
+		}, { // malformed envelope -> poison message
+			Body:           `not json`,
+			ExpectedPoison: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		mockStruct := new(mock.Mock)
+		handled := make(chan struct{}, 1)
+		poisoned := make(chan struct{}, 1)
+		for method, args := range testCase.ExpectedMethods {
+			mockStruct.On(method, args...).Return(nil).Run(func(mock.Arguments) {
+				handled <- struct{}{}
+			})
+		}
+
+		eventRouter := subscriber.Router(subscriber.Poison(func(*subscriber.Message, error) {
+			poisoned <- struct{}{}
+		}))
+		eventRouter.Handle(EventConsulNodesChanged, newDefaultMockHandler(mockStruct).ChangeConsulNodes)
+
+		broker := subscriber.MemoryBroker()
+		defaultSubscriber := subscriber.Default(subscriber.MessageBroker(broker))
+		defaultSubscriber.RegisterHandler(queue, eventRouter.Route)
+		assert.Nil(t, defaultSubscriber.StartListening())
+		assert.Nil(t, broker.Publish(queue, &subscriber.Message{ID: "message-1", Body: []byte(testCase.Body)}))
+
+		select {
+		case <-handled:
+			assert.False(t, testCase.ExpectedPoison, "message is handled, but expected to be poisoned, body: %s", testCase.Body)
+		case <-poisoned:
+			assert.True(t, testCase.ExpectedPoison, "message is poisoned unexpectedly, body: %s", testCase.Body)
+		case <-time.After(time.Second * 3):
+			t.Fatalf("message is not handled in time, body: %s", testCase.Body)
+		}
+		assert.Nil(t, defaultSubscriber.StopListening())
+		mockStruct.AssertExpectations(t)
+	}
+}
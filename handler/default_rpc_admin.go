@@ -1,8 +1,8 @@
 package handler
 
 import (
-	"bytes"
 	consulagent "club/consul/agent"
+	"club/db"
 	"club/model"
 	authproto "club/proto/golang/auth"
 	clubproto "club/proto/golang/club"
@@ -13,8 +13,6 @@ import (
 	"context"
 	"fmt"
 	mysqlcode "github.com/VividCortex/mysqlerr"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-playground/validator/v10"
 	"github.com/go-sql-driver/mysql"
 	"github.com/micro/go-micro/v2/client"
@@ -25,7 +23,6 @@ import (
 	"github.com/uber/jaeger-client-go"
 	"gorm.io/gorm"
 	"net/http"
-	"time"
 )
 
 func (d *_default) CreateNewClub(ctx context.Context, req *clubproto.CreateNewClubRequest, resp *clubproto.CreateNewClubResponse) (_ error) {
@@ -53,7 +50,7 @@ func (d *_default) CreateNewClub(ctx context.Context, req *clubproto.CreateNewCl
 	}
 
 	spanForConsul := d.tracer.StartSpan("GetNextServiceNode", opentracing.ChildOf(parentSpan))
-	selectedNode, err := d.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := d.consulAgent.GetNextServiceNodeWithContext(ctx, topic.AuthServiceName)
 	spanForConsul.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedNode", selectedNode), log.Error(err))
 	spanForConsul.Finish()
 
@@ -78,7 +75,7 @@ func (d *_default) CreateNewClub(ctx context.Context, req *clubproto.CreateNewCl
 		UUID:         req.UUID,
 		StudentUUIDs: req.MemberUUIDs,
 	}
-	callOpts := []client.CallOption{client.WithDialTimeout(time.Second * 2), client.WithRequestTimeout(time.Second * 3), client.WithAddress(selectedNode.Address)}
+	callOpts := []client.CallOption{client.WithDialTimeout(d.runtimeConfig.AuthDialTimeout()), client.WithRequestTimeout(d.runtimeConfig.AuthRequestTimeout()), client.WithAddress(selectedNode.Address)}
 	respOfReq, err := d.authStudent.GetStudentInformsWithUUIDs(md, authReq, callOpts...)
 	spanForReq.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", authReq), log.Object("response", respOfReq), log.Error(err))
 	spanForReq.Finish()
@@ -126,228 +123,228 @@ func (d *_default) CreateNewClub(ctx context.Context, req *clubproto.CreateNewCl
 		return
 	}
 
-	access := d.accessManage.BeginTx()
-
-	cUUID, ok := ctx.Value("ClubUUID").(string)
-	if !ok || cUUID == "" {
-		cUUID = fmt.Sprintf("club-%s", random.StringConsistOfIntWithLength(12))
-	}
-
-	for {
-		spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-		selectedClub, err := access.GetClubWithClubUUID(cUUID)
-		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-		spanForDB.Finish()
-		if err == gorm.ErrRecordNotFound {
-			break
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	committed := d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		cUUID, ok := ctx.Value("ClubUUID").(string)
+		if !ok || cUUID == "" {
+			cUUID = fmt.Sprintf("club-%s", random.StringConsistOfIntWithLength(12))
 		}
-		if err != nil {
-			access.Rollback()
-			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected error in GetClubWithClubUUID, err: " + err.Error())
-			return
-		}
-		cUUID = fmt.Sprintf("club-%s", random.StringConsistOfIntWithLength(12))
-		continue
-	}
 
-	spanForDB := d.tracer.StartSpan("CreateClub", opentracing.ChildOf(parentSpan))
-	createdClub, err := access.CreateClub(&model.Club{
-		UUID:       model.UUID(cUUID),
-		LeaderUUID: model.LeaderUUID(req.LeaderUUID),
-	})
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedClub", createdClub), log.Error(err))
-	spanForDB.Finish()
-
-	// Handling Error of CreateClub Method
-	switch assertedError := err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club model, err: " + err.Error())
-		return
-	case *mysql.MySQLError:
-		access.Rollback()
-		switch assertedError.Number {
-		case mysqlcode.ER_DUP_ENTRY:
-			key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
+		for {
+			spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+			selectedClub, err := access.GetClubWithClubUUID(ctx, cUUID)
+			spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+			spanForDB.Finish()
+			if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+				return abortTx(err)
+			}
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
 			if err != nil {
 				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: " + err.Error())
-				return
+				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected error in GetClubWithClubUUID, err: " + err.Error())
+				return abortTx(err)
 			}
-			switch key {
-			case model.ClubInstance.LeaderUUID.KeyName():
-				resp.Status = http.StatusConflict
-				resp.Code = code.ClubLeaderAlreadyExist
-				resp.Message = fmt.Sprintf(conflictMessageFormat, "club with that leader uuid is already exist, entry: " + entry)
-				return
+			cUUID = fmt.Sprintf("club-%s", random.StringConsistOfIntWithLength(12))
+			continue
+		}
+
+		spanForDB := d.tracer.StartSpan("CreateClub", opentracing.ChildOf(parentSpan))
+		createdClub, err := access.CreateClub(ctx, &model.Club{
+			UUID:       model.UUID(cUUID),
+			LeaderUUID: model.LeaderUUID(req.LeaderUUID),
+		})
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedClub", createdClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
+
+		// Handling Error of CreateClub Method
+		switch assertedError := err.(type) {
+		case nil:
+			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club model, err: " + err.Error())
+			return abortTx(err)
+		case *mysql.MySQLError:
+			switch assertedError.Number {
+			case mysqlcode.ER_DUP_ENTRY:
+				key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
+				if err != nil {
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: " + err.Error())
+					return abortTx(err)
+				}
+				switch key {
+				case model.ClubInstance.LeaderUUID.KeyName():
+					resp.Status = http.StatusConflict
+					resp.Code = code.ClubLeaderAlreadyExist
+					resp.Message = fmt.Sprintf(conflictMessageFormat, "club with that leader uuid is already exist, entry: " + entry)
+					return abortTx(err)
+				default:
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: " + key)
+					return abortTx(err)
+				}
 			default:
 				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: " + key)
-				return
+				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClub MySQL error code, err: " + assertedError.Error())
+				return abortTx(err)
 			}
 		default:
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClub MySQL error code, err: " + assertedError.Error())
-			return
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClub errors, err: " + assertedError.Error())
+			return abortTx(err)
 		}
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClub errors, err: " + assertedError.Error())
-		return
-	}
 
-	if string(req.Logo) == "" {
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "Logo attribute cannot be null")
-		return
-	}
+		if string(req.Logo) == "" {
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "Logo attribute cannot be null")
+			return abortTx(err)
+		}
 
-	logoURI := fmt.Sprintf("logos/%s", string(createdClub.UUID))
-	spanForDB = d.tracer.StartSpan("CreateClubInform", opentracing.ChildOf(parentSpan))
-	createdInform, err := access.CreateClubInform(&model.ClubInform{
-		ClubUUID: model.ClubUUID(cUUID),
-		Name:     model.Name(req.Name),
-		Field:    model.Field(req.Field),
-		Location: model.Location(req.Location),
-		Floor:    model.Floor(req.Floor),
-		LogoURI:  model.LogoURI(logoURI),
-	})
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedInform", createdInform), log.Error(err))
-	spanForDB.Finish()
+		logoURI := fmt.Sprintf("logos/%s", string(createdClub.UUID))
+		spanForDB = d.tracer.StartSpan("CreateClubInform", opentracing.ChildOf(parentSpan))
+		createdInform, err := access.CreateClubInform(ctx, &model.ClubInform{
+			ClubUUID: model.ClubUUID(cUUID),
+			Name:     model.Name(req.Name),
+			Field:    model.Field(req.Field),
+			Location: model.Location(req.Location),
+			Floor:    model.Floor(req.Floor),
+			LogoURI:  model.LogoURI(logoURI),
+		})
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedInform", createdInform), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	// Handling Error of CreateClubInform Method
-	switch assertedError := err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club inform model, err: " + err.Error())
-		return
-	case *mysql.MySQLError:
-		access.Rollback()
-		switch assertedError.Number {
-		case mysqlcode.ER_DUP_ENTRY:
-			key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
-			if err != nil {
-				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: " + err.Error())
-				return
-			}
-			switch key {
-			case model.ClubInformInstance.Name.KeyName():
-				resp.Status = http.StatusConflict
-				resp.Code = code.ClubNameDuplicate
-				resp.Message = fmt.Sprintf(conflictMessageFormat, "that club name is alreay exist, entry: " + entry)
-				return
-			case model.ClubInformInstance.Location.KeyName():
-				resp.Status = http.StatusConflict
-				resp.Code = code.ClubLocationDuplicate
-				resp.Message = fmt.Sprintf(conflictMessageFormat, "that club location is alreay exist, entry: " + entry)
-				return
+		// Handling Error of CreateClubInform Method
+		switch assertedError := err.(type) {
+		case nil:
+			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club inform model, err: " + err.Error())
+			return abortTx(err)
+		case *mysql.MySQLError:
+			switch assertedError.Number {
+			case mysqlcode.ER_DUP_ENTRY:
+				key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
+				if err != nil {
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: " + err.Error())
+					return abortTx(err)
+				}
+				switch key {
+				case model.ClubInformInstance.Name.KeyName():
+					resp.Status = http.StatusConflict
+					resp.Code = code.ClubNameDuplicate
+					resp.Message = fmt.Sprintf(conflictMessageFormat, "that club name is alreay exist, entry: " + entry)
+					return abortTx(err)
+				case model.ClubInformInstance.Location.KeyName():
+					resp.Status = http.StatusConflict
+					resp.Code = code.ClubLocationDuplicate
+					resp.Message = fmt.Sprintf(conflictMessageFormat, "that club location is alreay exist, entry: " + entry)
+					return abortTx(err)
+				default:
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: " + key)
+					return abortTx(err)
+				}
 			default:
 				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: " + key)
-				return
+				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClubInform MySQL error code, err: " + assertedError.Error())
+				return abortTx(err)
 			}
 		default:
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClubInform MySQL error code, err: " + assertedError.Error())
-			return
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClubInform errors, err: " + assertedError.Error())
+			return abortTx(err)
 		}
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClubInform errors, err: " + assertedError.Error())
-		return
-	}
 
-	createdMembers := make([]*model.ClubMember, len(req.MemberUUIDs))
-	spanForDB = d.tracer.StartSpan("CreateClubMembers", opentracing.ChildOf(parentSpan))
-	for index, memberUUID := range req.MemberUUIDs {
-		createdMember, createErr := access.CreateClubMember(&model.ClubMember{
-			ClubUUID:    model.ClubUUID(cUUID),
-			StudentUUID: model.StudentUUID(memberUUID),
-		})
-		if createErr != nil {
-			err = createErr
-			break
+		createdMembers := make([]*model.ClubMember, len(req.MemberUUIDs))
+		spanForDB = d.tracer.StartSpan("CreateClubMembers", opentracing.ChildOf(parentSpan))
+		for index, memberUUID := range req.MemberUUIDs {
+			createdMember, createErr := access.CreateClubMember(ctx, &model.ClubMember{
+				ClubUUID:    model.ClubUUID(cUUID),
+				StudentUUID: model.StudentUUID(memberUUID),
+			})
+			if createErr != nil {
+				err = createErr
+				break
+			}
+			createdMembers[index] = createdMember
+		}
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedMembers", createdMembers), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
 		}
-		createdMembers[index] = createdMember
-	}
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedMembers", createdMembers), log.Error(err))
-	spanForDB.Finish()
 
-	switch assertedError := err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club member model, err: "+err.Error())
-		return
-	case *mysql.MySQLError:
-		access.Rollback()
-		switch assertedError.Number {
-		case mysqlcode.ER_DUP_ENTRY:
-			key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
-			if err != nil {
-				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: "+err.Error())
-				return
-			}
-			switch key {
-			case model.ClubMemberInstance.StudentUUID.KeyName():
-				resp.Status = http.StatusConflict
-				resp.Code = code.ClubMemberDuplicate
-				resp.Message = fmt.Sprintf(conflictMessageFormat, "that club member is already exist, entry: "+entry)
-				return
+		switch assertedError := err.(type) {
+		case nil:
+			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club member model, err: "+err.Error())
+			return abortTx(err)
+		case *mysql.MySQLError:
+			switch assertedError.Number {
+			case mysqlcode.ER_DUP_ENTRY:
+				key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
+				if err != nil {
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: "+err.Error())
+					return abortTx(err)
+				}
+				switch key {
+				case model.ClubMemberInstance.StudentUUID.KeyName():
+					resp.Status = http.StatusConflict
+					resp.Code = code.ClubMemberDuplicate
+					resp.Message = fmt.Sprintf(conflictMessageFormat, "that club member is already exist, entry: "+entry)
+					return abortTx(err)
+				default:
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: "+key)
+					return abortTx(err)
+				}
 			default:
 				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: "+key)
-				return
+				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClubMember MySQL error code, err: "+assertedError.Error())
+				return abortTx(err)
 			}
 		default:
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClubMember MySQL error code, err: "+assertedError.Error())
-			return
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClubMember errors, err: "+assertedError.Error())
+			return abortTx(err)
 		}
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClubMember errors, err: "+assertedError.Error())
+
+		resp.Status = http.StatusCreated
+		resp.Message = "new club create success"
+		resp.ClubUUID = cUUID
+
+		return nil
+	})
+	if !committed {
 		return
 	}
 
-	if d.awsSession != nil {
+	// logo is uploaded after commit, so that logo of club which is not created isn't left in storage
+	if d.logoStorage != nil {
 		spanForS3 := d.tracer.StartSpan("PutObject", opentracing.ChildOf(parentSpan))
-		_, err = s3.New(d.awsSession).PutObject(&s3.PutObjectInput{
-			Bucket: aws.String(s3Bucket),
-			Key:    aws.String(logoURI),
-			Body:   bytes.NewReader(req.Logo),
-			ACL:    aws.String("public-read"),
-		})
+		err = d.logoStorage.Put(fmt.Sprintf("logos/%s", resp.ClubUUID), req.Logo)
 		spanForS3.SetTag("X-Request-Id", reqID).LogFields(log.Error(err))
 		spanForS3.Finish()
 
 		if err != nil {
-			access.Rollback()
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to upload profile to s3, err: " + err.Error())
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "club is created but unable to upload logo to storage, upload it again with ModifyClubInform, err: " + err.Error())
 			return
 		}
 	}
-
-	access.Commit()
-	resp.Status = http.StatusCreated
-	resp.Message = "new club create success"
-	resp.ClubUUID = cUUID
-
 	return
 }
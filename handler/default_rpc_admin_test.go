@@ -29,7 +29,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -77,7 +77,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus:  http.StatusForbidden,
 		}, { // invalid request
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -103,7 +103,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 		}, { // invalid request (floor -> in 1~5)
 			Floor: "100",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -129,7 +129,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusProxyAuthRequired,
 		}, { // invalid request
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -157,7 +157,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 		}, { // invalid request (logo not exist)
 			Logo: []byte(test.EmptyReplaceValueForString),
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -189,7 +189,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111", "student-222222222222"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -214,7 +214,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111", "student-222222222222"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -238,7 +238,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -252,7 +252,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -266,7 +266,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -280,7 +280,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -291,21 +291,21 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{}, errors.New("I don't know what error is")},
+				"GetNextServiceNodeWithContext": {&registry.Node{}, errors.New("I don't know what error is")},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // GetNextServiceNode return ErrAvailableNodeNotFound
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{}, consulagent.ErrAvailableNodeNotFound},
+				"GetNextServiceNodeWithContext": {&registry.Node{}, consulagent.ErrAvailableNodeNotFound},
 			},
 			ExpectedStatus: http.StatusServiceUnavailable,
 		}, { // GetClubWithClubUUID unexpected error
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -331,7 +331,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -359,7 +359,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -386,7 +386,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -411,7 +411,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClub return invalid message in duplicate error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -436,7 +436,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClub return unexpected error code
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -462,7 +462,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 		}, { // Club Name Duplicate error
 			Name: "DMS",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -490,7 +490,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 		}, { // Club Location Duplicate error
 			Location: "2-2반 교실",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -518,7 +518,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 		}, { // CreateClubInform return unexpected duplicate error
 			Location: "2-2반 교실",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -545,7 +545,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 		}, { // CreateClubInform return unexpected type of error
 			Location: "2-2반 교실",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -571,7 +571,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClubInform returns invalid message in duplicate error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -597,7 +597,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClubInform returns unexpected mysql error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -625,7 +625,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			LeaderUUID:  "student-111111111111",
 			MemberUUIDs: []string{"student-111111111111", "student-111111111111"},
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -661,7 +661,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedCode:   code.ClubMemberDuplicate,
 		}, { // CreateClubMembers returns unexpected duplicate error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -688,7 +688,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClubMembers returns unexpected type of error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -715,7 +715,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClubMembers returns invalid message in duplicate error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -742,7 +742,7 @@ func Test_default_CreateNewClub(t *testing.T) {
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClubMembers returns unexpected mysql error
 			ExpectedMethods: map[test.Method]test.Returns{
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -1,8 +1,9 @@
 package handler
 
 import (
-	"bytes"
 	consulagent "club/consul/agent"
+	"club/db"
+	accesserrors "club/db/access/errors"
 	"club/model"
 	authproto "club/proto/golang/auth"
 	clubproto "club/proto/golang/club"
@@ -14,8 +15,6 @@ import (
 	"errors"
 	"fmt"
 	mysqlcode "github.com/VividCortex/mysqlerr"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-playground/validator/v10"
 	"github.com/go-sql-driver/mysql"
 	"github.com/micro/go-micro/v2/client"
@@ -55,39 +54,9 @@ func (d *_default) AddClubMember(ctx context.Context, req *clubproto.AddClubMemb
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
-
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
-
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
-
+	// student inform is resolved before transaction, so that row lock is not held while waiting for response of auth service
 	spanForConsul := d.tracer.StartSpan("GetNextServiceNode", opentracing.ChildOf(parentSpan))
-	selectedNode, err := d.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := d.consulAgent.GetNextServiceNodeWithContext(ctx, topic.AuthServiceName)
 	spanForConsul.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedNode", selectedNode), log.Error(err))
 	spanForConsul.Finish()
 
@@ -95,12 +64,10 @@ func (d *_default) AddClubMember(ctx context.Context, req *clubproto.AddClubMemb
 	case nil:
 		break
 	case consulagent.ErrAvailableNodeNotFound:
-		access.Rollback()
 		resp.Status = http.StatusServiceUnavailable
 		resp.Message = fmt.Sprintf(serviceUnavailableMessageFormat, "there is no available server, service name: " + topic.AuthServiceName)
 		return
 	default:
-		access.Rollback()
 		resp.Status = http.StatusInternalServerError
 		resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to query in consul agent, err: " + err.Error())
 		return
@@ -113,7 +80,7 @@ func (d *_default) AddClubMember(ctx context.Context, req *clubproto.AddClubMemb
 		UUID:        req.UUID,
 		StudentUUID: req.StudentUUID,
 	}
-	callOpts := []client.CallOption{client.WithDialTimeout(time.Second * 2), client.WithRequestTimeout(time.Second * 3), client.WithAddress(selectedNode.Address)}
+	callOpts := []client.CallOption{client.WithDialTimeout(d.runtimeConfig.AuthDialTimeout()), client.WithRequestTimeout(d.runtimeConfig.AuthRequestTimeout()), client.WithAddress(selectedNode.Address)}
 	respOfReq, err := d.authStudent.GetStudentInformWithUUID(md, authReq, callOpts...)
 	spanForReq.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", authReq), log.Object("response", respOfReq), log.Error(err))
 	spanForReq.Finish()
@@ -124,18 +91,15 @@ func (d *_default) AddClubMember(ctx context.Context, req *clubproto.AddClubMemb
 	case *microerrors.Error:
 		switch assertedError.Code {
 		case http.StatusRequestTimeout:
-			access.Rollback()
 			resp.Status = http.StatusRequestTimeout
 			resp.Message = fmt.Sprintf(requestTimeoutMessageFormat, assertedError.Detail)
 			return
 		default:
-			access.Rollback()
 			resp.Status = http.StatusInternalServerError
 			resp.Message = fmt.Sprintf(internalServerMessageFormat, assertedError.Detail)
 			return
 		}
 	default:
-		access.Rollback()
 		resp.Status = http.StatusInternalServerError
 		resp.Message = fmt.Sprintf(internalServerMessageFormat, assertedError.Error())
 		return
@@ -145,65 +109,96 @@ func (d *_default) AddClubMember(ctx context.Context, req *clubproto.AddClubMemb
 	case http.StatusOK:
 		break
 	case http.StatusNotFound:
-		access.Rollback()
 		resp.Status = http.StatusNotFound
 		resp.Code = code.NotFoundStudentNoExist
 		resp.Message = fmt.Sprintf(notFoundMessageFormat, "student with that uuid not eixst")
 		return
 	default:
-		access.Rollback()
 		resp.Status = respOfReq.Status
 		resp.Message = fmt.Sprintf("GetStudentInformWithUUID unexpected status returned, message: %s", respOfReq.Message)
 		return
 	}
 
-	spanForDB = d.tracer.StartSpan("CreateClubMember", opentracing.ChildOf(parentSpan))
-	createdMember, err := access.CreateClubMember(&model.ClubMember{
-		ClubUUID:    model.ClubUUID(req.ClubUUID),
-		StudentUUID: model.StudentUUID(req.StudentUUID),
-	})
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedMember", createdMember), log.Error(err))
-	spanForDB.Finish()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch assertedError := err.(type) {
-	case nil:
-		break
-	case *mysql.MySQLError:
-		access.Rollback()
-		switch assertedError.Number {
-		case mysqlcode.ER_DUP_ENTRY:
-			key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
-			if err != nil {
-				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: " + err.Error())
-				return
-			}
-			switch key {
-			case model.ClubMemberInstance.StudentUUID.KeyName():
-				resp.Status = http.StatusConflict
-				resp.Code = code.ClubMemberAlreadyExist
-				resp.Message = fmt.Sprintf(conflictMessageFormat, "alreay exists as member, entry: " + entry)
-				return
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
+
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
+
+		spanForDB = d.tracer.StartSpan("CreateClubMember", opentracing.ChildOf(parentSpan))
+		createdMember, err := access.CreateClubMember(ctx, &model.ClubMember{
+			ClubUUID:    model.ClubUUID(req.ClubUUID),
+			StudentUUID: model.StudentUUID(req.StudentUUID),
+		})
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedMember", createdMember), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
+
+		switch assertedError := err.(type) {
+		case nil:
+			break
+		case *mysql.MySQLError:
+			switch assertedError.Number {
+			case mysqlcode.ER_DUP_ENTRY:
+				key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
+				if err != nil {
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to parse MySQL duplicate error, err: " + err.Error())
+					return abortTx(err)
+				}
+				switch key {
+				case model.ClubMemberInstance.StudentUUID.KeyName():
+					resp.Status = http.StatusConflict
+					resp.Code = code.ClubMemberAlreadyExist
+					resp.Message = fmt.Sprintf(conflictMessageFormat, "alreay exists as member, entry: " + entry)
+					return abortTx(err)
+				default:
+					resp.Status = http.StatusInternalServerError
+					resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: " + key)
+					return abortTx(err)
+				}
 			default:
 				resp.Status = http.StatusInternalServerError
-				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected duplicate entry, key: " + key)
-				return
+				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClubMember MySQL error code, err: " + assertedError.Error())
+				return abortTx(err)
 			}
 		default:
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected CreateClubMember MySQL error code, err: " + assertedError.Error())
-			return
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClubMember errors, err: " + assertedError.Error())
+			return abortTx(err)
 		}
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected type of CreateClubMember errors, err: " + assertedError.Error())
-		return
-	}
 
-	access.Commit()
-	resp.Status = http.StatusCreated
-	resp.Message = "success to create new club member"
+		resp.Status = http.StatusCreated
+		resp.Message = "success to create new club member"
+		return nil
+	})
 	return
 }
 
@@ -230,60 +225,62 @@ func (d *_default) DeleteClubMember(ctx context.Context, req *clubproto.DeleteCl
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("DeleteClubMember", opentracing.ChildOf(parentSpan))
-	err, rowAffected := access.DeleteClubMember(req.ClubUUID, req.StudentUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("DeleteClubMember", opentracing.ChildOf(parentSpan))
+		err, rowAffected := access.DeleteClubMember(ctx, req.ClubUUID, req.StudentUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClubMember returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClubMember returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if rowAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubMemberNoExist
-		resp.Message = fmt.Sprintf("club member with that student uuid not exist")
-		return
-	}
+		if rowAffected == 0 {
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubMemberNoExist
+			resp.Message = fmt.Sprintf("club member with that student uuid not exist")
+			return abortTx(err)
+		}
 
-	access.Commit()
-	resp.Status = http.StatusOK
-	resp.Message = "success delete club member"
+		resp.Status = http.StatusOK
+		resp.Message = "success delete club member"
+		return nil
+	})
 	return
 }
 
@@ -310,120 +307,121 @@ func (d *_default) ChangeClubLeader(ctx context.Context, req *clubproto.ChangeCl
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
 
-	if req.NewLeaderUUID == string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusConflict
-		resp.Code = code.AlreadyClubLeader
-		resp.Message = fmt.Sprintf("that student is already club leader")
-		return
-	}
+		if req.NewLeaderUUID == string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusConflict
+			resp.Code = code.AlreadyClubLeader
+			resp.Message = fmt.Sprintf("that student is already club leader")
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("GetClubMembersWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedMembers, err := access.GetClubMembersWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("GetClubMembersWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedMembers, err := access.GetClubMembersWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if err != nil && err != gorm.ErrRecordNotFound {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubMembersWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil && err != gorm.ErrRecordNotFound {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubMembersWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	memberUUIDs := make([]string, len(selectedMembers))
-	for index, selectedMember := range selectedMembers {
-		memberUUIDs[index] = string(selectedMember.StudentUUID)
-	}
+		memberUUIDs := make([]string, len(selectedMembers))
+		for index, selectedMember := range selectedMembers {
+			memberUUIDs[index] = string(selectedMember.StudentUUID)
+		}
 
-	if !contains(memberUUIDs, req.NewLeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubMemberNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "member to be club leader is not exists")
-		return
-	}
+		if !contains(memberUUIDs, req.NewLeaderUUID) {
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubMemberNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "member to be club leader is not exists")
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("ChangeClubLeader", opentracing.ChildOf(parentSpan))
-	err, rowAffected := access.ChangeClubLeader(req.ClubUUID, req.NewLeaderUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("ChangeClubLeader", opentracing.ChildOf(parentSpan))
+		err, rowAffected := access.ChangeClubLeader(ctx, req.ClubUUID, req.NewLeaderUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch assertedError := err.(type) {
-	case nil:
-		break
-	case *mysql.MySQLError:
-		switch assertedError.Number {
-		case mysqlcode.ER_DUP_ENTRY:
-			key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
-			if err != nil {
-				err = errors.New("unable to parse ChangeClubLeader duplicate error, err: " + err.Error())
-				break
-			}
-			switch key {
-			case model.ClubInstance.LeaderUUID.KeyName():
-				access.Rollback()
-				resp.Status = http.StatusConflict
-				resp.Code = code.ClubLeaderDuplicateForChange
-				resp.Message = fmt.Sprintf(conflictMessageFormat, "that leader uuid is already other club's leader, entry: " + entry)
-				return
+		switch assertedError := err.(type) {
+		case nil:
+			break
+		case *mysql.MySQLError:
+			switch assertedError.Number {
+			case mysqlcode.ER_DUP_ENTRY:
+				key, entry, err := mysqlerr.ParseDuplicateEntryErrorFrom(assertedError)
+				if err != nil {
+					err = errors.New("unable to parse ChangeClubLeader duplicate error, err: " + err.Error())
+					break
+				}
+				switch key {
+				case model.ClubInstance.LeaderUUID.KeyName():
+					resp.Status = http.StatusConflict
+					resp.Code = code.ClubLeaderDuplicateForChange
+					resp.Message = fmt.Sprintf(conflictMessageFormat, "that leader uuid is already other club's leader, entry: " + entry)
+					return abortTx(err)
+				default:
+					err = errors.New("unexpected duplicate entry, key: " + key)
+				}
 			default:
-				err = errors.New("unexpected duplicate entry, key: " + key)
+				err = errors.New("unexpected ChangeClubLeader MySQL error code, err: " + assertedError.Error())
 			}
 		default:
-			err = errors.New("unexpected ChangeClubLeader MySQL error code, err: " + assertedError.Error())
+			err = errors.New("unexpected type of ChangeClubLeader error, err: " + assertedError.Error())
 		}
-	default:
-		err = errors.New("unexpected type of ChangeClubLeader error, err: " + assertedError.Error())
-	}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, err.Error())
+			return abortTx(err)
+		}
 
-	if rowAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "ChangeClubLeader returns 0 row affected")
-		return
-	}
+		if rowAffected == 0 {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "ChangeClubLeader returns 0 row affected")
+			return abortTx(err)
+		}
 
-	access.Commit()
-	resp.Status = http.StatusOK
-	resp.Message = "success change club leader"
+		resp.Status = http.StatusOK
+		resp.Message = "success change club leader"
+		return nil
+	})
 	return
 }
 
@@ -450,90 +448,107 @@ func (d *_default) ModifyClubInform(ctx context.Context, req *clubproto.ModifyCl
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
-
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+	// version read by editor is passed with metadata, it is not checked if it doesn't exist or flag is off for editor
+	version, err := versionFromContext(ctx)
+	if err != nil {
+		resp.Status = http.StatusProxyAuthRequired
+		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid Version in metadata, err: " + err.Error())
 		return
 	}
-
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
+	if !d.isFeatureEnabled(flagVersionCheck, req.UUID) {
+		version = 0
 	}
 
-	spanForDB = d.tracer.StartSpan("ModifyClubInform", opentracing.ChildOf(parentSpan))
-	err, rowAffected := access.ModifyClubInform(req.ClubUUID, &model.ClubInform{
-		ClubConcept:  model.ClubConcept(req.ClubConcept),
-		Introduction: model.Introduction(req.Introduction),
-		Link:         model.Link(req.Link),
-	})
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
-	spanForDB.Finish()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	committed := d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch assertedError := err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club inform model, err: " + assertedError.Error())
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyClubInform returns unexpected error, err: " + assertedError.Error())
-		return
-	}
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if rowAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyClubInform returns 0 row affected")
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
+
+		spanForDB = d.tracer.StartSpan("ModifyClubInform", opentracing.ChildOf(parentSpan))
+		err, rowAffected := access.ModifyClubInform(ctx, req.ClubUUID, version, &model.ClubInform{
+			ClubConcept:  model.ClubConcept(req.ClubConcept),
+			Introduction: model.Introduction(req.Introduction),
+			Link:         model.Link(req.Link),
+		})
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
+
+		if err == accesserrors.VersionConflict {
+			resp.Status = http.StatusConflict
+			resp.Code = clubInformVersionConflict
+			resp.Message = fmt.Sprintf(conflictMessageFormat, "club inform is already modified by other request, read it again and retry")
+			return abortTx(err)
+		}
+
+		switch assertedError := err.(type) {
+		case nil:
+			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club inform model, err: " + assertedError.Error())
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyClubInform returns unexpected error, err: " + assertedError.Error())
+			return abortTx(err)
+		}
+
+		if rowAffected == 0 {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyClubInform returns 0 row affected")
+			return abortTx(err)
+		}
+
+		resp.Status = http.StatusOK
+		resp.Message = "success modify club inform"
+		return nil
+	})
+	if !committed {
 		return
 	}
 
-	if d.awsSession != nil && (string(req.Logo) != "") {
+	// logo is uploaded after commit, so that logo isn't changed if club inform is not modified
+	if d.logoStorage != nil && (string(req.Logo) != "") {
 		spanForS3 := d.tracer.StartSpan("PutObject", opentracing.ChildOf(parentSpan))
-		_, err = s3.New(d.awsSession).PutObject(&s3.PutObjectInput{
-			Bucket: aws.String(s3Bucket),
-			Key:    aws.String(fmt.Sprintf("logos/%s", req.ClubUUID)),
-			Body:   bytes.NewReader(req.Logo),
-			ACL:    aws.String("public-read"),
-		})
+		err = d.logoStorage.Put(fmt.Sprintf("logos/%s", req.ClubUUID), req.Logo)
 		spanForS3.SetTag("X-Request-Id", reqID).LogFields(log.Error(err))
 		spanForS3.Finish()
 
 		if err != nil {
-			access.Rollback()
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unable to upload profile to s3, err: " + err.Error())
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "club inform is modified but unable to upload logo to storage, err: " + err.Error())
 			return
 		}
 	}
-
-	access.Commit()
-	resp.Status = http.StatusOK
-	resp.Message = "success modify club inform"
 	return
 }
 
@@ -560,111 +575,117 @@ func (d *_default) DeleteClubWithUUID(ctx context.Context, req *clubproto.Delete
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetClubWithClubUUIDForUpdate", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUIDForUpdate(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUIDForUpdate returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
 
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
+		spanForDB = d.tracer.StartSpan("GetCurrentRecruitmentWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedRecruit, err := access.GetCurrentRecruitmentWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("GetCurrentRecruitmentWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedRecruit, err := access.GetCurrentRecruitmentWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
-	spanForDB.Finish()
+		switch err {
+		case gorm.ErrRecordNotFound:
+			break
+		case nil:
+			resp.Status = http.StatusConflict
+			resp.Code = code.RecruitmentInProgressExist
+			resp.Message = fmt.Sprintf(conflictMessageFormat, "there is recruitment which is in progress")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	switch err {
-	case gorm.ErrRecordNotFound:
-		break
-	case nil:
-		access.Rollback()
-		resp.Status = http.StatusConflict
-		resp.Code = code.RecruitmentInProgressExist
-		resp.Message = fmt.Sprintf(conflictMessageFormat, "there is recruitment which is in progress")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		spanForDB = d.tracer.StartSpan("DeleteClub", opentracing.ChildOf(parentSpan))
+		err, rowsAffected := access.DeleteClub(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowsAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("DeleteClub", opentracing.ChildOf(parentSpan))
-	err, rowsAffected := access.DeleteClub(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowsAffected)), log.Error(err))
-	spanForDB.Finish()
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClub returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClub returns unexpected error, err: " + err.Error())
-		return
-	}
+		if rowsAffected == 0 {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClub returns 0 rows affected")
+			return abortTx(err)
+		}
 
-	if rowsAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClub returns 0 rows affected")
-		return
-	}
+		spanForDB = d.tracer.StartSpan("DeleteClubInform", opentracing.ChildOf(parentSpan))
+		err, rowsAffected = access.DeleteClubInform(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowsAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("DeleteClubInform", opentracing.ChildOf(parentSpan))
-	err, rowsAffected = access.DeleteClubInform(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowsAffected)), log.Error(err))
-	spanForDB.Finish()
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClubInform returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClubInform returns unexpected error, err: " + err.Error())
-		return
-	}
+		if rowsAffected == 0 {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClubInform returns 0 rows affected")
+			return abortTx(err)
+		}
 
-	if rowsAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteClubInform returns 0 rows affected")
-		return
-	}
-
-	spanForDB = d.tracer.StartSpan("DeleteAllClubMembers", opentracing.ChildOf(parentSpan))
-	err, rowsAffected = access.DeleteAllClubMembers(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowsAffected)), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("DeleteAllClubMembers", opentracing.ChildOf(parentSpan))
+		err, rowsAffected = access.DeleteAllClubMembers(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowsAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteAllClubMembers returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteAllClubMembers returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	access.Commit()
-	resp.Status = http.StatusOK
-	resp.Message = "succeed to delete club"
+		resp.Status = http.StatusOK
+		resp.Message = "succeed to delete club"
+		return nil
+	})
 	return
 }
 
@@ -691,192 +712,194 @@ func (d *_default) RegisterRecruitment(ctx context.Context, req *clubproto.Regis
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
-
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundClubNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
-
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
-
-	spanForDB = d.tracer.StartSpan("GetCurrentRecruitmentWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedRecruit, err := access.GetCurrentRecruitmentWithClubUUID(req.ClubUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
-	spanForDB.Finish()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetClubWithClubUUIDForUpdate", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUIDForUpdate(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch err {
-	case gorm.ErrRecordNotFound:
-		break
-	case nil:
-		access.Rollback()
-		resp.Status = http.StatusConflict
-		resp.Code = code.RecruitmentInProgressAlreadyExist
-		resp.Message = fmt.Sprintf(conflictMessageFormat, "recruitment in progress is already exists")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundClubNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "club with that uuid not exist")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUIDForUpdate returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	rUUID, ok := ctx.Value("RecruitmentUUID").(string)
-	if !ok || rUUID == "" {
-		rUUID = fmt.Sprintf("recruitment-%s", random.StringConsistOfIntWithLength(12))
-	}
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
 
-	for {
-		spanForDB := d.tracer.StartSpan("GetRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
-		selectedClub, err := access.GetRecruitmentWithRecruitmentUUID(rUUID)
-		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB = d.tracer.StartSpan("GetCurrentRecruitmentWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedRecruit, err := access.GetCurrentRecruitmentWithClubUUID(ctx, req.ClubUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
 		spanForDB.Finish()
-		if err == gorm.ErrRecordNotFound {
-			break
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
 		}
-		if err != nil {
-			access.Rollback()
+
+		switch err {
+		case gorm.ErrRecordNotFound:
+			break
+		case nil:
+			resp.Status = http.StatusConflict
+			resp.Code = code.RecruitmentInProgressAlreadyExist
+			resp.Message = fmt.Sprintf(conflictMessageFormat, "recruitment in progress is already exists")
+			return abortTx(err)
+		default:
 			resp.Status = http.StatusInternalServerError
-			resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected error in GetRecruitmentWithRecruitmentUUID, err: " + err.Error())
-			return
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
 		}
-		rUUID = fmt.Sprintf("recruitment-%s", random.StringConsistOfIntWithLength(12))
-		continue
-	}
 
-	now := time.Now()
-	startTime := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-	endTime := time.Time{}
-	if req.EndPeriod != "" {
-		endTimeSplice := strings.Split(req.EndPeriod, "-")
-		if len(endTimeSplice) != 3 {
-			access.Rollback()
-			resp.Status = http.StatusProxyAuthRequired
-			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid EndPeriod value")
-			return
+		rUUID, ok := ctx.Value("RecruitmentUUID").(string)
+		if !ok || rUUID == "" {
+			rUUID = fmt.Sprintf("recruitment-%s", random.StringConsistOfIntWithLength(12))
 		}
 
-		err = nil
-		const indexForYear = 0
-		const indexForMonth = 1
-		const indexForDay = 2
-		year, convertErr := strconv.Atoi(endTimeSplice[indexForYear])
-		if len(endTimeSplice[indexForYear]) != 4 || convertErr != nil { err = errors.New("year invalid") }
-		month, convertErr := strconv.Atoi(endTimeSplice[indexForMonth])
-		if len(endTimeSplice[indexForMonth]) != 2 || convertErr != nil { err = errors.New("month invalid") }
-		day, convertErr := strconv.Atoi(endTimeSplice[indexForDay])
-		if len(endTimeSplice[indexForDay]) != 2 || convertErr != nil { err = errors.New("day invalid") }
-
-		if err != nil {
-			access.Rollback()
-			resp.Status = http.StatusProxyAuthRequired
-			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid EndPeriod value")
-			return
+		for {
+			spanForDB := d.tracer.StartSpan("GetRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
+			selectedClub, err := access.GetRecruitmentWithRecruitmentUUID(ctx, rUUID)
+			spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+			spanForDB.Finish()
+			if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+				return abortTx(err)
+			}
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
+			if err != nil {
+				resp.Status = http.StatusInternalServerError
+				resp.Message = fmt.Sprintf(internalServerMessageFormat, "unexpected error in GetRecruitmentWithRecruitmentUUID, err: " + err.Error())
+				return abortTx(err)
+			}
+			rUUID = fmt.Sprintf("recruitment-%s", random.StringConsistOfIntWithLength(12))
+			continue
 		}
 
-		endTime = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
-	}
+		now := time.Now()
+		startTime := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		endTime := time.Time{}
+		if req.EndPeriod != "" {
+			endTimeSplice := strings.Split(req.EndPeriod, "-")
+			if len(endTimeSplice) != 3 {
+				resp.Status = http.StatusProxyAuthRequired
+				resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid EndPeriod value")
+				return abortTx(err)
+			}
 
-	if !reflect.DeepEqual(endTime, time.Time{}) && endTime.Add(time.Hour).Sub(startTime).Milliseconds() < 0 {
-		access.Rollback()
-		resp.Status = http.StatusConflict
-		resp.Code = code.EndPeriodOlderThanNow
-		resp.Message = fmt.Sprintf(conflictMessageFormat, "end period is older than now")
-		return
-	}
+			err = nil
+			const indexForYear = 0
+			const indexForMonth = 1
+			const indexForDay = 2
+			year, convertErr := strconv.Atoi(endTimeSplice[indexForYear])
+			if len(endTimeSplice[indexForYear]) != 4 || convertErr != nil { err = errors.New("year invalid") }
+			month, convertErr := strconv.Atoi(endTimeSplice[indexForMonth])
+			if len(endTimeSplice[indexForMonth]) != 2 || convertErr != nil { err = errors.New("month invalid") }
+			day, convertErr := strconv.Atoi(endTimeSplice[indexForDay])
+			if len(endTimeSplice[indexForDay]) != 2 || convertErr != nil { err = errors.New("day invalid") }
 
-	spanForDB = d.tracer.StartSpan("CreateRecruitment", opentracing.ChildOf(parentSpan))
-	createdRecruitment, err := access.CreateRecruitment(&model.ClubRecruitment{
-		UUID:           model.UUID(rUUID),
-		ClubUUID:       model.ClubUUID(req.ClubUUID),
-		RecruitConcept: model.RecruitConcept(req.RecruitConcept),
-		StartPeriod:    model.StartPeriod(startTime),
-		EndPeriod:      model.EndPeriod(endTime),
-	})
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedRecruitment", createdRecruitment), log.Error(err))
-	spanForDB.Finish()
+			if err != nil {
+				resp.Status = http.StatusProxyAuthRequired
+				resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid EndPeriod value")
+				return abortTx(err)
+			}
 
-	switch err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club recruit model")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "CreateRecruitment returns unexpected error, err: " + err.Error())
-		return
-	}
+			endTime = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+		}
 
-	if len(req.RecruitMembers) == 0 {
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "recruit member list is empty")
-		return
-	}
+		if !reflect.DeepEqual(endTime, time.Time{}) && endTime.Add(time.Hour).Sub(startTime).Milliseconds() < 0 {
+			resp.Status = http.StatusConflict
+			resp.Code = code.EndPeriodOlderThanNow
+			resp.Message = fmt.Sprintf(conflictMessageFormat, "end period is older than now")
+			return abortTx(err)
+		}
 
-	createdRecruitMembers := make([]*model.RecruitMember, len(req.RecruitMembers))
-	spanForDB = d.tracer.StartSpan("CreateRecruitMembers", opentracing.ChildOf(parentSpan))
-	for index, recruitMember := range req.RecruitMembers {
-		createdRecruitMember, commandErr := access.CreateRecruitMember(&model.RecruitMember{
-			RecruitmentUUID: model.RecruitmentUUID(string(createdRecruitment.UUID)),
-			Grade:           model.Grade(recruitMember.Grade),
-			Field:           model.Field(recruitMember.Field),
-			Number:          model.Number(recruitMember.Number),
+		spanForDB = d.tracer.StartSpan("CreateRecruitment", opentracing.ChildOf(parentSpan))
+		createdRecruitment, err := access.CreateRecruitment(ctx, &model.ClubRecruitment{
+			UUID:           model.UUID(rUUID),
+			ClubUUID:       model.ClubUUID(req.ClubUUID),
+			RecruitConcept: model.RecruitConcept(req.RecruitConcept),
+			StartPeriod:    model.StartPeriod(startTime),
+			EndPeriod:      model.EndPeriod(endTime),
 		})
-		if commandErr != nil {
-			err = commandErr
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedRecruitment", createdRecruitment), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
+
+		switch err.(type) {
+		case nil:
 			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club recruit model")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "CreateRecruitment returns unexpected error, err: " + err.Error())
+			return abortTx(err)
 		}
-		createdRecruitMembers[index] = createdRecruitMember
-	}
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedRecruitMembers", createdRecruitMembers), log.Error(err))
-	spanForDB.Finish()
 
-	switch err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club recruit model")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "CreateRecruitment returns unexpected error, err: " + err.Error())
-		return
-	}
+		if len(req.RecruitMembers) == 0 {
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "recruit member list is empty")
+			return abortTx(err)
+		}
+
+		createdRecruitMembers := make([]*model.RecruitMember, len(req.RecruitMembers))
+		spanForDB = d.tracer.StartSpan("CreateRecruitMembers", opentracing.ChildOf(parentSpan))
+		for index, recruitMember := range req.RecruitMembers {
+			createdRecruitMember, commandErr := access.CreateRecruitMember(ctx, &model.RecruitMember{
+				RecruitmentUUID: model.RecruitmentUUID(string(createdRecruitment.UUID)),
+				Grade:           model.Grade(recruitMember.Grade),
+				Field:           model.Field(recruitMember.Field),
+				Number:          model.Number(recruitMember.Number),
+			})
+			if commandErr != nil {
+				err = commandErr
+				break
+			}
+			createdRecruitMembers[index] = createdRecruitMember
+		}
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedRecruitMembers", createdRecruitMembers), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
+
+		switch err.(type) {
+		case nil:
+			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club recruit model")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "CreateRecruitment returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	access.Commit()
-	resp.Status = http.StatusCreated
-	resp.RecruitmentUUID = string(createdRecruitment.UUID)
-	resp.Message = "succeed to register club recruitment"
+		resp.Status = http.StatusCreated
+		resp.RecruitmentUUID = string(createdRecruitment.UUID)
+		resp.Message = "succeed to register club recruitment"
+		return nil
+	})
 	return
 }
 
@@ -903,125 +926,149 @@ func (d *_default) ModifyRecruitment(ctx context.Context, req *clubproto.ModifyR
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetCurrentRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
-	selectedRecruit, err := access.GetCurrentRecruitmentWithRecruitmentUUID(req.RecruitmentUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
-	spanForDB.Finish()
-
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundCurrentRecruitmentNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "recruitment which is in progress not exists")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithRecruitmentUUID returns unexpected error, err: " + err.Error())
+	// version read by editor is passed with metadata, it is not checked if it doesn't exist or flag is off for editor
+	version, err := versionFromContext(ctx)
+	if err != nil {
+		resp.Status = http.StatusProxyAuthRequired
+		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid Version in metadata, err: " + err.Error())
 		return
 	}
+	if !d.isFeatureEnabled(flagVersionCheck, req.UUID) {
+		version = 0
+	}
+
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetCurrentRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
+		selectedRecruit, err := access.GetCurrentRecruitmentWithRecruitmentUUID(ctx, req.RecruitmentUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(string(selectedRecruit.ClubUUID))
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundCurrentRecruitmentNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "recruitment which is in progress not exists")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithRecruitmentUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		spanForDB = d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUID(ctx, string(selectedRecruit.ClubUUID))
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("ModifyRecruitment", opentracing.ChildOf(parentSpan))
-	err, rowAffected := access.ModifyRecruitment(string(selectedRecruit.UUID), &model.ClubRecruitment{
-		RecruitConcept: model.RecruitConcept(req.RecruitConcept),
-	})
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
-	spanForDB.Finish()
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyRecruitment returns unexpected error, err: " + err.Error())
-		return
-	}
+		spanForDB = d.tracer.StartSpan("ModifyRecruitment", opentracing.ChildOf(parentSpan))
+		err, rowAffected := access.ModifyRecruitment(ctx, string(selectedRecruit.UUID), version, &model.ClubRecruitment{
+			RecruitConcept: model.RecruitConcept(req.RecruitConcept),
+		})
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if rowAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyRecruitment returns 0 rows affected")
-		return
-	}
+		if err == accesserrors.VersionConflict {
+			resp.Status = http.StatusConflict
+			resp.Code = recruitmentVersionConflict
+			resp.Message = fmt.Sprintf(conflictMessageFormat, "recruitment is already modified by other request, read it again and retry")
+			return abortTx(err)
+		}
 
-	if len(req.RecruitMembers) == 0 {
-		access.Commit()
-		resp.Status = http.StatusOK
-		resp.Message = "succeed to modify club recruitment"
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyRecruitment returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("DeleteAllRecruitMember", opentracing.ChildOf(parentSpan))
-	err, rowAffected = access.DeleteAllRecruitMember(string(selectedRecruit.UUID))
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
-	spanForDB.Finish()
+		if rowAffected == 0 {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "ModifyRecruitment returns 0 rows affected")
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteAllRecruitMember returns unexpected error, err: " + err.Error())
-		return
-	}
+		if len(req.RecruitMembers) == 0 {
+			resp.Status = http.StatusOK
+			resp.Message = "succeed to modify club recruitment"
+			return nil
+		}
 
-	createdRecruitMembers := make([]*model.RecruitMember, len(req.RecruitMembers))
-	spanForDB = d.tracer.StartSpan("CreateRecruitMembers", opentracing.ChildOf(parentSpan))
-	for index, recruitMember := range req.RecruitMembers {
-		createdRecruitMember, commandErr := access.CreateRecruitMember(&model.RecruitMember{
-			RecruitmentUUID: model.RecruitmentUUID(string(selectedRecruit.UUID)),
-			Grade:           model.Grade(recruitMember.Grade),
-			Field:           model.Field(recruitMember.Field),
-			Number:          model.Number(recruitMember.Number),
-		})
-		if commandErr != nil {
-			err = commandErr
-			break
+		spanForDB = d.tracer.StartSpan("DeleteAllRecruitMember", opentracing.ChildOf(parentSpan))
+		err, rowAffected = access.DeleteAllRecruitMember(ctx, string(selectedRecruit.UUID))
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowAffected", int(rowAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
 		}
-		createdRecruitMembers[index] = createdRecruitMember
-	}
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedRecruitMembers", createdRecruitMembers), log.Error(err))
-	spanForDB.Finish()
 
-	switch err.(type) {
-	case nil:
-		break
-	case validator.ValidationErrors:
-		access.Rollback()
-		resp.Status = http.StatusProxyAuthRequired
-		resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club recruit model")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "CreateRecruitMembers returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteAllRecruitMember returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
+
+		createdRecruitMembers := make([]*model.RecruitMember, len(req.RecruitMembers))
+		spanForDB = d.tracer.StartSpan("CreateRecruitMembers", opentracing.ChildOf(parentSpan))
+		for index, recruitMember := range req.RecruitMembers {
+			createdRecruitMember, commandErr := access.CreateRecruitMember(ctx, &model.RecruitMember{
+				RecruitmentUUID: model.RecruitmentUUID(string(selectedRecruit.UUID)),
+				Grade:           model.Grade(recruitMember.Grade),
+				Field:           model.Field(recruitMember.Field),
+				Number:          model.Number(recruitMember.Number),
+			})
+			if commandErr != nil {
+				err = commandErr
+				break
+			}
+			createdRecruitMembers[index] = createdRecruitMember
+		}
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("CreatedRecruitMembers", createdRecruitMembers), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	access.Commit()
-	resp.Status = http.StatusOK
-	resp.Message = "succeed to modify club recruitment"
+		switch err.(type) {
+		case nil:
+			break
+		case validator.ValidationErrors:
+			resp.Status = http.StatusProxyAuthRequired
+			resp.Message = fmt.Sprintf(proxyAuthRequiredMessageFormat, "invalid data for club recruit model")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "CreateRecruitMembers returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
+
+		resp.Status = http.StatusOK
+		resp.Message = "succeed to modify club recruitment"
+		return nil
+	})
 	return
 }
 
@@ -1048,82 +1095,88 @@ func (d *_default) DeleteRecruitmentWithUUID(ctx context.Context, req *clubproto
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
-
-	spanForDB := d.tracer.StartSpan("GetCurrentRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
-	selectedRecruit, err := access.GetCurrentRecruitmentWithRecruitmentUUID(req.RecruitmentUUID)
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
-	spanForDB.Finish()
+	// whole unit of work is run again if it fails with retryable error like deadlock, so it must write response in each attempt
+	d.runInTx(ctx, &resp.Status, &resp.Message, func(access db.Accessor) error {
+		spanForDB := d.tracer.StartSpan("GetCurrentRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
+		selectedRecruit, err := access.GetCurrentRecruitmentWithRecruitmentUUID(ctx, req.RecruitmentUUID)
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruit", selectedRecruit), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	switch err {
-	case nil:
-		break
-	case gorm.ErrRecordNotFound:
-		access.Rollback()
-		resp.Status = http.StatusNotFound
-		resp.Code = code.NotFoundCurrentRecruitmentNoExist
-		resp.Message = fmt.Sprintf(notFoundMessageFormat, "recruitment which is in progress not exists")
-		return
-	default:
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithRecruitmentUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			resp.Status = http.StatusNotFound
+			resp.Code = code.NotFoundCurrentRecruitmentNoExist
+			resp.Message = fmt.Sprintf(notFoundMessageFormat, "recruitment which is in progress not exists")
+			return abortTx(err)
+		default:
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentWithRecruitmentUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(string(selectedRecruit.ClubUUID))
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
+		selectedClub, err := access.GetClubWithClubUUID(ctx, string(selectedRecruit.ClubUUID))
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
-		access.Rollback()
-		resp.Status = http.StatusForbidden
-		resp.Code = code.ForbiddenNotClubLeader
-		resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
-		return
-	}
+		if !adminUUIDRegex.MatchString(req.UUID) && req.UUID != string(selectedClub.LeaderUUID) {
+			resp.Status = http.StatusForbidden
+			resp.Code = code.ForbiddenNotClubLeader
+			resp.Message = fmt.Sprintf(forbiddenMessageFormat, "you're not admin and not club leader")
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("DeleteRecruitment", opentracing.ChildOf(parentSpan))
-	err, rowsAffected := access.DeleteRecruitment(string(selectedRecruit.UUID))
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowsAffected", int(rowsAffected)), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("DeleteRecruitment", opentracing.ChildOf(parentSpan))
+		err, rowsAffected := access.DeleteRecruitment(ctx, string(selectedRecruit.UUID))
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowsAffected", int(rowsAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteRecruitment returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteRecruitment returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	if rowsAffected == 0 {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteRecruitment returns 0 rows affected")
-		return
-	}
+		if rowsAffected == 0 {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteRecruitment returns 0 rows affected")
+			return abortTx(err)
+		}
 
-	spanForDB = d.tracer.StartSpan("DeleteAllRecruitMember", opentracing.ChildOf(parentSpan))
-	err, rowsAffected = access.DeleteAllRecruitMember(string(selectedRecruit.UUID))
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowsAffected", int(rowsAffected)), log.Error(err))
-	spanForDB.Finish()
+		spanForDB = d.tracer.StartSpan("DeleteAllRecruitMember", opentracing.ChildOf(parentSpan))
+		err, rowsAffected = access.DeleteAllRecruitMember(ctx, string(selectedRecruit.UUID))
+		spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int("RowsAffected", int(rowsAffected)), log.Error(err))
+		spanForDB.Finish()
+		if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+			return abortTx(err)
+		}
 
-	if err != nil {
-		access.Rollback()
-		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteAllRecruitMember returns unexpected error, err: " + err.Error())
-		return
-	}
+		if err != nil {
+			resp.Status = http.StatusInternalServerError
+			resp.Message = fmt.Sprintf(internalServerMessageFormat, "DeleteAllRecruitMember returns unexpected error, err: " + err.Error())
+			return abortTx(err)
+		}
 
-	access.Commit()
-	resp.Status = http.StatusOK
-	resp.Message = "succeed to delete club recruitment"
+		resp.Status = http.StatusOK
+		resp.Message = "succeed to delete club recruitment"
+		return nil
+	})
 	return
 }
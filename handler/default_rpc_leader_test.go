@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"club/config"
+	accesserrors "club/db/access/errors"
 	test "club/handler/for_test"
 	"club/model"
 	authproto "club/proto/golang/auth"
@@ -34,7 +36,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -65,7 +67,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -112,6 +114,20 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-333333333333",
 			ExpectedMethods: map[test.Method]test.Returns{
+				"GetNextServiceNodeWithContext": {&registry.Node{
+					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
+					Address: "127.0.0.1:10101",
+				}, nil},
+				"GetStudentInformWithUUID": {&authproto.GetStudentInformWithUUIDResponse{
+					Status:        http.StatusOK,
+					Message:       "get student inform success",
+					Grade:         2,
+					Group:         2,
+					StudentNumber: 7,
+					Name:          "박진홍",
+					PhoneNumber:   "01088378347",
+					ImageURI:      "profiles/student-111111111111",
+				}, nil},
 				"BeginTx": {},
 				"GetClubWithClubUUID": {&model.Club{
 					UUID:       "club-111111111111",
@@ -126,6 +142,20 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
+				"GetNextServiceNodeWithContext": {&registry.Node{
+					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
+					Address: "127.0.0.1:10101",
+				}, nil},
+				"GetStudentInformWithUUID": {&authproto.GetStudentInformWithUUIDResponse{
+					Status:        http.StatusOK,
+					Message:       "get student inform success",
+					Grade:         2,
+					Group:         2,
+					StudentNumber: 7,
+					Name:          "박진홍",
+					PhoneNumber:   "01088378347",
+					ImageURI:      "profiles/student-111111111111",
+				}, nil},
 				"BeginTx":             {},
 				"GetClubWithClubUUID": {&model.Club{}, gorm.ErrRecordNotFound},
 				"Rollback":            {&gorm.DB{}},
@@ -137,6 +167,20 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
+				"GetNextServiceNodeWithContext": {&registry.Node{
+					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
+					Address: "127.0.0.1:10101",
+				}, nil},
+				"GetStudentInformWithUUID": {&authproto.GetStudentInformWithUUIDResponse{
+					Status:        http.StatusOK,
+					Message:       "get student inform success",
+					Grade:         2,
+					Group:         2,
+					StudentNumber: 7,
+					Name:          "박진홍",
+					PhoneNumber:   "01088378347",
+					ImageURI:      "profiles/student-111111111111",
+				}, nil},
 				"BeginTx":             {},
 				"GetClubWithClubUUID": {&model.Club{}, errors.New("unexpected error")},
 				"Rollback":            {&gorm.DB{}},
@@ -147,13 +191,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{}, errors.New("I don't know what error is")},
-				"Rollback":           {&gorm.DB{}},
+				"GetNextServiceNodeWithContext": {&registry.Node{}, errors.New("I don't know what error is")},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // GetNextServiceNode return ErrAvailableNodeNotFound
@@ -161,13 +199,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{}, consulagent.ErrAvailableNodeNotFound},
-				"Rollback":           {&gorm.DB{}},
+				"GetNextServiceNodeWithContext": {&registry.Node{}, consulagent.ErrAvailableNodeNotFound},
 			},
 			ExpectedStatus: http.StatusServiceUnavailable,
 		}, { // GetStudentInformWithUUID response 404
@@ -175,12 +207,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -188,7 +215,6 @@ func Test_Default_AddClubMember(t *testing.T) {
 					Status:  http.StatusNotFound,
 					Message: "student uuid not exist",
 				}, nil},
-				"Rollback": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusNotFound,
 			ExpectedCode:   code.NotFoundStudentNoExist,
@@ -197,12 +223,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -210,7 +231,6 @@ func Test_Default_AddClubMember(t *testing.T) {
 					Status:  http.StatusNetworkAuthenticationRequired,
 					Message: "I don't know about this error",
 				}, nil},
-				"Rollback": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusNetworkAuthenticationRequired,
 		}, { // GetStudentInformWithUUID response not 200 or 404
@@ -218,12 +238,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -231,7 +246,6 @@ func Test_Default_AddClubMember(t *testing.T) {
 					Status:  http.StatusNetworkAuthenticationRequired,
 					Message: "I don't know about this error",
 				}, nil},
-				"Rollback": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusNetworkAuthenticationRequired,
 		}, { // GetStudentInformWithUUID response timeout error
@@ -239,12 +253,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -252,7 +261,6 @@ func Test_Default_AddClubMember(t *testing.T) {
 					Code:   http.StatusRequestTimeout,
 					Detail: "request time out",
 				}},
-				"Rollback": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusRequestTimeout,
 		}, { // GetStudentInformWithUUID response unexpected error code
@@ -260,12 +268,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -273,7 +276,6 @@ func Test_Default_AddClubMember(t *testing.T) {
 					Code:   http.StatusNetworkAuthenticationRequired,
 					Detail: "I don't know about this error",
 				}},
-				"Rollback": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // GetStudentInformWithUUID response unexpected type of error
@@ -281,17 +283,11 @@ func Test_Default_AddClubMember(t *testing.T) {
 			ClubUUID:    "club-111111111111",
 			StudentUUID: "student-222222222222",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
-					UUID:       "club-111111111111",
-					LeaderUUID: "student-111111111111",
-				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
 				"GetStudentInformWithUUID": {&authproto.GetStudentInformWithUUIDResponse{}, errors.New("unexpected error")},
-				"Rollback":                 {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // CreateClubMember returns duplicate error
@@ -304,7 +300,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -333,7 +329,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -361,7 +357,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -392,7 +388,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -423,7 +419,7 @@ func Test_Default_AddClubMember(t *testing.T) {
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
-				"GetNextServiceNode": {&registry.Node{
+				"GetNextServiceNodeWithContext": {&registry.Node{
 					Id:      "DMS.SMS.v1.service.auth-6b37b034-5f0b-4c9f-a03a-decbcb3799ef",
 					Address: "127.0.0.1:10101",
 				}, nil},
@@ -1033,6 +1029,21 @@ func Test_Default_ModifyClubInform(t *testing.T) {
 				"Rollback":         {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
+		}, { // ModifyClubInform returns version conflict error
+			UUID:     "student-111111111111",
+			ClubUUID: "club-111111111111",
+			Version:  1,
+			ExpectedMethods: map[test.Method]test.Returns{
+				"BeginTx": {},
+				"GetClubWithClubUUID": {&model.Club{
+					UUID:       "club-111111111111",
+					LeaderUUID: "student-111111111111",
+				}, nil},
+				"ModifyClubInform": {accesserrors.VersionConflict, 0},
+				"Rollback":         {&gorm.DB{}},
+			},
+			ExpectedStatus: http.StatusConflict,
+			ExpectedCode:   clubInformVersionConflict,
 		},
 	}
 
@@ -1058,6 +1069,110 @@ func Test_Default_ModifyClubInform(t *testing.T) {
 	}
 }
 
+func Test_Default_ModifyClubInform_RetryOnDeadlock(t *testing.T) {
+	deadlockErr := &mysql.MySQLError{Number: mysqlcode.ER_LOCK_DEADLOCK, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+
+	testCase := test.ModifyClubInformCase{
+		UUID:     "student-111111111111",
+		ClubUUID: "club-111111111111",
+		ExpectedMethods: map[test.Method]test.Returns{
+			"BeginTx": {},
+			"GetClubWithClubUUID": {&model.Club{
+				UUID:       "club-111111111111",
+				LeaderUUID: "student-111111111111",
+			}, nil},
+			"Rollback": {&gorm.DB{}},
+			"Commit":   {&gorm.DB{}},
+		},
+		ExpectedStatus: http.StatusOK,
+	}
+
+	newMock := &mock.Mock{}
+	handler := newDefaultMockHandler(newMock)
+
+	testCase.ChangeEmptyValueToValidValue()
+	testCase.ChangeEmptyReplaceValueToEmptyValue()
+	testCase.OnExpectMethodsTo(newMock)
+
+	// first transaction fails with deadlock, and whole unit of work is run again in new transaction
+	revisionInform := &model.ClubInform{
+		ClubConcept:  model.ClubConcept(testCase.ClubConcept),
+		Introduction: model.Introduction(testCase.Introduction),
+		Link:         model.Link(testCase.Link),
+	}
+	newMock.On("ModifyClubInform", testCase.ClubUUID, uint(testCase.Version), revisionInform).Return(deadlockErr, 0).Once()
+	newMock.On("ModifyClubInform", testCase.ClubUUID, uint(testCase.Version), revisionInform).Return(nil, 1).Once()
+
+	req := new(clubproto.ModifyClubInformRequest)
+	testCase.SetRequestContextOf(req)
+	ctx := testCase.GetMetadataContext()
+
+	resp := new(clubproto.ModifyClubInformResponse)
+	_ = handler.ModifyClubInform(ctx, req, resp)
+
+	assert.Equalf(t, int(testCase.ExpectedStatus), int(resp.Status), "status assertion error (message: %s)", resp.Message)
+	newMock.AssertNumberOfCalls(t, "BeginTx", 2)
+	newMock.AssertNumberOfCalls(t, "ModifyClubInform", 2)
+	newMock.AssertNumberOfCalls(t, "Rollback", 1)
+	newMock.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func Test_Default_ModifyClubInform_VersionCheckFlag(t *testing.T) {
+	tests := []struct {
+		FlagOn          bool
+		ExpectedVersion uint
+	}{
+		{ // flag is on for editor -> version in metadata is checked
+			FlagOn:          true,
+			ExpectedVersion: 1,
+		}, { // flag is off for editor -> version is not checked
+			FlagOn:          false,
+			ExpectedVersion: 0,
+		},
+	}
+
+	for _, testCase := range tests {
+		modifyCase := test.ModifyClubInformCase{
+			UUID:     "student-111111111111",
+			ClubUUID: "club-111111111111",
+			Version:  1,
+			ExpectedMethods: map[test.Method]test.Returns{
+				"BeginTx": {},
+				"GetClubWithClubUUID": {&model.Club{
+					UUID:       "club-111111111111",
+					LeaderUUID: "student-111111111111",
+				}, nil},
+				"Commit": {&gorm.DB{}},
+			},
+		}
+
+		newMock := &mock.Mock{}
+		handler := newDefaultMockHandler(newMock)
+		featureFlags := config.Flags()
+		featureFlags.Override(flagVersionCheck, testCase.FlagOn)
+		handler.featureFlags = featureFlags
+
+		modifyCase.ChangeEmptyValueToValidValue()
+		modifyCase.ChangeEmptyReplaceValueToEmptyValue()
+		modifyCase.OnExpectMethodsTo(newMock)
+		newMock.On("ModifyClubInform", modifyCase.ClubUUID, testCase.ExpectedVersion, &model.ClubInform{
+			ClubConcept:  model.ClubConcept(modifyCase.ClubConcept),
+			Introduction: model.Introduction(modifyCase.Introduction),
+			Link:         model.Link(modifyCase.Link),
+		}).Return(nil, 1)
+
+		req := new(clubproto.ModifyClubInformRequest)
+		modifyCase.SetRequestContextOf(req)
+		ctx := modifyCase.GetMetadataContext()
+
+		resp := new(clubproto.ModifyClubInformResponse)
+		_ = handler.ModifyClubInform(ctx, req, resp)
+
+		assert.Equalf(t, http.StatusOK, int(resp.Status), "status assertion error (test case: %v, message: %s)", testCase, resp.Message)
+		newMock.AssertExpectations(t)
+	}
+}
+
 func Test_Default_DeleteClubWithUUID(t *testing.T) {
 	tests := []test.DeleteClubWithUUIDCase{
 		{ // success case (student uuid)
@@ -1065,7 +1180,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1081,7 +1196,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1118,7 +1233,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID:      "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1126,23 +1241,23 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			},
 			ExpectedStatus: http.StatusForbidden,
 			ExpectedCode:   code.ForbiddenNotClubLeader,
-		}, { // GetClubWithClubUUID returns not found error
+		}, { // GetClubWithClubUUIDForUpdate returns not found error
 			UUID:          "student-111111111111",
 			ClubUUID:      "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":             {},
-				"GetClubWithClubUUID": {&model.Club{}, gorm.ErrRecordNotFound},
-				"Rollback":            {&gorm.DB{}},
+				"BeginTx":                      {},
+				"GetClubWithClubUUIDForUpdate": {&model.Club{}, gorm.ErrRecordNotFound},
+				"Rollback":                     {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusNotFound,
 			ExpectedCode:   code.NotFoundClubNoExist,
-		}, { // GetClubWithClubUUID returns unexpected error
+		}, { // GetClubWithClubUUIDForUpdate returns unexpected error
 			UUID:          "admin-111111111111",
 			ClubUUID:      "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":             {},
-				"GetClubWithClubUUID": {&model.Club{}, errors.New("unexpected error")},
-				"Rollback":            {&gorm.DB{}},
+				"BeginTx":                      {},
+				"GetClubWithClubUUIDForUpdate": {&model.Club{}, errors.New("unexpected error")},
+				"Rollback":                     {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // GetCurrentRecruitmentWithClubUUID returns value
@@ -1150,7 +1265,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1168,7 +1283,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1181,7 +1296,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1195,7 +1310,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1209,7 +1324,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1224,7 +1339,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1239,7 +1354,7 @@ func Test_Default_DeleteClubWithUUID(t *testing.T) {
 			ClubUUID: "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1282,7 +1397,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		{ // success case (student uuid)
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1298,7 +1413,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			EndPeriod: time.Now().Format("2006-01-02"),
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1336,7 +1451,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			ClubUUID:      "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1344,29 +1459,29 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			},
 			ExpectedStatus: http.StatusForbidden,
 			ExpectedCode:   code.ForbiddenNotClubLeader,
-		}, { // GetClubWithClubUUID returns not found error
+		}, { // GetClubWithClubUUIDForUpdate returns not found error
 			UUID:          "student-111111111111",
 			ClubUUID:      "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":             {},
-				"GetClubWithClubUUID": {&model.Club{}, gorm.ErrRecordNotFound},
-				"Rollback":            {&gorm.DB{}},
+				"BeginTx":                      {},
+				"GetClubWithClubUUIDForUpdate": {&model.Club{}, gorm.ErrRecordNotFound},
+				"Rollback":                     {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusNotFound,
 			ExpectedCode:   code.NotFoundClubNoExist,
-		}, { // GetClubWithClubUUID returns unexpected error
+		}, { // GetClubWithClubUUIDForUpdate returns unexpected error
 			UUID:          "admin-111111111111",
 			ClubUUID:      "club-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":             {},
-				"GetClubWithClubUUID": {&model.Club{}, errors.New("unexpected error")},
-				"Rollback":            {&gorm.DB{}},
+				"BeginTx":                      {},
+				"GetClubWithClubUUIDForUpdate": {&model.Club{}, errors.New("unexpected error")},
+				"Rollback":                     {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		}, { // GetCurrentRecruitmentWithClubUUID returns recruit
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1382,7 +1497,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		}, { // GetCurrentRecruitmentWithClubUUID returns unexpected error
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1393,7 +1508,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		}, { // GetRecruitmentWithRecruitmentUUID returns unexpected error
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1405,7 +1520,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		}, { // CreateRecruitment returns validate error
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1419,7 +1534,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			EndPeriod: "InvalidPeriod",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1432,7 +1547,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			EndPeriod: "01021-132-sad",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1445,7 +1560,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			EndPeriod: "2020-10-14",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1458,7 +1573,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		}, { // CreateRecruitment returns unexpected error
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1472,7 +1587,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 			RecruitMembers: test.EmptyReplaceValueForRecruitMembers,
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1485,7 +1600,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		}, { // CreateRecruitMembers returns validate error
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1499,7 +1614,7 @@ func Test_Default_RegisterRecruitment(t *testing.T) {
 		}, { // CreateRecruitMembers returns unexpected error
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetClubWithClubUUID": {&model.Club{
+				"GetClubWithClubUUIDForUpdate": {&model.Club{
 					UUID:       "club-111111111111",
 					LeaderUUID: "student-111111111111",
 				}, nil},
@@ -1721,6 +1836,27 @@ func Test_Default_ModifyRecruitment(t *testing.T) {
 				"Rollback":          {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
+		}, { // ModifyRecruitment returns version conflict error
+			UUID:               "admin-111111111111",
+			RecruitmentUUID:    "recruitment-111111111111",
+			RecruitmentConcept: "강제 모집 종료 예정",
+			Version:            1,
+			ExpectedMethods: map[test.Method]test.Returns{
+				"BeginTx": {},
+				"GetCurrentRecruitmentWithRecruitmentUUID": {&model.ClubRecruitment{
+					UUID:           "recruitment-111111111111",
+					ClubUUID:       "club-111111111111",
+					RecruitConcept: "첫 번째 상시 채용",
+				}, nil},
+				"GetClubWithClubUUID": {&model.Club{
+					UUID:       "club-111111111111",
+					LeaderUUID: "student-111111111111",
+				}, nil},
+				"ModifyRecruitment": {accesserrors.VersionConflict, 0},
+				"Rollback":          {&gorm.DB{}},
+			},
+			ExpectedStatus: http.StatusConflict,
+			ExpectedCode:   recruitmentVersionConflict,
 		}, { // DeleteAllRecruitMember returns unexpected error
 			UUID:            "student-111111111111",
 			RecruitmentUUID: "recruitment-111111111111",
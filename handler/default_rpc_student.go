@@ -13,8 +13,6 @@ import (
 	"net/http"
 )
 
-const defaultCountValue = 10
-
 func (d *_default) GetClubsSortByUpdateTime(ctx context.Context, req *clubproto.GetClubsSortByUpdateTimeRequest, resp *clubproto.GetClubsSortByUpdateTimeResponse) (_ error) {
 	ctx, proxyAuthenticated, reason := d.getContextFromMetadata(ctx)
 	if !proxyAuthenticated {
@@ -37,13 +35,17 @@ func (d *_default) GetClubsSortByUpdateTime(ctx context.Context, req *clubproto.
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
-	if req.Count == 0 { req.Count = defaultCountValue }
+	if req.Count == 0 { req.Count = uint32(d.runtimeConfig.DefaultCountValue()) }
 	spanForDB := d.tracer.StartSpan("GetClubInformsSortByUpdateTime", opentracing.ChildOf(parentSpan))
-	selectedInforms, err := access.GetClubInformsSortByUpdateTime(int(req.Start), int(req.Count), req.Field, req.Name)
+	selectedInforms, err := access.GetClubInformsSortByUpdateTime(ctx, int(req.Start), int(req.Count), req.Field, req.Name)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedInforms", selectedInforms), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -75,19 +77,32 @@ func (d *_default) GetClubsSortByUpdateTime(ctx context.Context, req *clubproto.
 		}
 	}
 
-	spanForDB = d.tracer.StartSpan("GetClubsWithClubUUIDs", opentracing.ChildOf(parentSpan))
-	selectedClubs := make([]*model.Club, len(informsForResp))
+	clubUUIDs := make([]string, len(informsForResp))
 	for index, informForResp := range informsForResp {
-		selectedClub, queryErr := access.GetClubWithClubUUID(informForResp.ClubUUID)
-		if queryErr != nil {
-			err = queryErr
-			break
-		}
-		informsForResp[index].LeaderUUID = string(selectedClub.LeaderUUID)
-		selectedClubs[index] = selectedClub
+		clubUUIDs[index] = informForResp.ClubUUID
 	}
+	clubUUIDs = distinct(clubUUIDs)
+
+	spanForDB = d.tracer.StartSpan("GetClubsWithClubUUIDs", opentracing.ChildOf(parentSpan))
+	selectedClubs, err := access.GetClubsWithClubUUIDs(ctx, clubUUIDs)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClubs", selectedClubs), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
+
+	leaderUUIDs := make(map[string]string, len(selectedClubs))
+	for _, selectedClub := range selectedClubs {
+		leaderUUIDs[string(selectedClub.UUID)] = string(selectedClub.LeaderUUID)
+	}
+	for _, informForResp := range informsForResp {
+		leaderUUID, ok := leaderUUIDs[informForResp.ClubUUID]
+		if !ok && err == nil {
+			err = gorm.ErrRecordNotFound
+		}
+		informForResp.LeaderUUID = leaderUUID
+	}
 
 	if err != nil {
 		access.Rollback()
@@ -96,33 +111,31 @@ func (d *_default) GetClubsSortByUpdateTime(ctx context.Context, req *clubproto.
 		return
 	}
 
-	spanForDB = d.tracer.StartSpan("GetClubMembersListWithClubUUIDs", opentracing.ChildOf(parentSpan))
-	selectedMembersList := make([][]*model.ClubMember, len(informsForResp))
-	for index, informForResp := range informsForResp {
-		selectedMembers, queryErr := access.GetClubMembersWithClubUUID(informForResp.ClubUUID)
-		if queryErr == gorm.ErrRecordNotFound {
-			err = queryErr
-		} else if queryErr != nil {
-			err = queryErr
-			break
-		}
-		membersForResp := make([]string, len(selectedMembers))
-		for index, selectedMember := range selectedMembers {
-			membersForResp[index] = string(selectedMember.StudentUUID)
-		}
-		informsForResp[index].MemberUUIDs = membersForResp
-		selectedMembersList[index] = selectedMembers
-	}
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembersList", selectedMembersList), log.Error(err))
+	spanForDB = d.tracer.StartSpan("GetClubMembersWithClubUUIDs", opentracing.ChildOf(parentSpan))
+	selectedMembers, err := access.GetClubMembersWithClubUUIDs(ctx, clubUUIDs)
+	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubMembersWithClubUUID returns unexpected error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubMembersWithClubUUIDs returns unexpected error, err: " + err.Error())
 		return
 	}
 
+	memberUUIDs := map[string][]string{}
+	for _, selectedMember := range selectedMembers {
+		clubUUID := string(selectedMember.ClubUUID)
+		memberUUIDs[clubUUID] = append(memberUUIDs[clubUUID], string(selectedMember.StudentUUID))
+	}
+	for _, informForResp := range informsForResp {
+		informForResp.MemberUUIDs = append([]string{}, memberUUIDs[informForResp.ClubUUID]...)
+	}
+
 	access.Commit()
 	resp.Status = http.StatusOK
 	resp.Informs = informsForResp
@@ -153,13 +166,17 @@ func (d *_default) GetRecruitmentsSortByCreateTime(ctx context.Context, req *clu
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
-	if req.Count == 0 { req.Count = defaultCountValue }
+	if req.Count == 0 { req.Count = uint32(d.runtimeConfig.DefaultCountValue()) }
 	spanForDB := d.tracer.StartSpan("GetCurrentRecruitmentsSortByCreateTime", opentracing.ChildOf(parentSpan))
-	selectedRecruits, err := access.GetCurrentRecruitmentsSortByCreateTime(int(req.Start), int(req.Count), req.Field, req.Name)
+	selectedRecruits, err := access.GetCurrentRecruitmentsSortByCreateTime(ctx, int(req.Start), int(req.Count), req.Field, req.Name)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruits", selectedRecruits), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -194,37 +211,41 @@ func (d *_default) GetRecruitmentsSortByCreateTime(ctx context.Context, req *clu
 		recruitmentsForResp[index] = recruit
 	}
 
-	spanForDB = d.tracer.StartSpan("GetRecruitMembersListWithRecruitmentUUIDs", opentracing.ChildOf(parentSpan))
-	selectedMembersList := make([][]*model.RecruitMember, len(recruitmentsForResp))
+	recruitmentUUIDs := make([]string, len(recruitmentsForResp))
 	for index, recruitmentForResp := range recruitmentsForResp {
-		selectedMembers, queryErr := access.GetRecruitMembersWithRecruitmentUUID(recruitmentForResp.RecruitmentUUID)
-		if queryErr == gorm.ErrRecordNotFound {
-			err = queryErr
-		} else if queryErr != nil {
-			err = queryErr
-			break
-		}
-		membersForResp := make([]*clubproto.RecruitMember, len(selectedMembers))
-		for index, selectedMember := range selectedMembers {
-			membersForResp[index] = &clubproto.RecruitMember{
-				Grade:  string(selectedMember.Grade),
-				Field:  string(selectedMember.Field),
-				Number: string(selectedMember.Number),
-			}
-		}
-		recruitmentsForResp[index].RecruitMembers = membersForResp
-		selectedMembersList[index] = selectedMembers
+		recruitmentUUIDs[index] = recruitmentForResp.RecruitmentUUID
 	}
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembersList", selectedMembersList), log.Error(err))
+	recruitmentUUIDs = distinct(recruitmentUUIDs)
+
+	spanForDB = d.tracer.StartSpan("GetRecruitMembersWithRecruitmentUUIDs", opentracing.ChildOf(parentSpan))
+	selectedMembers, err := access.GetRecruitMembersWithRecruitmentUUIDs(ctx, recruitmentUUIDs)
+	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetRecruitMembersWithRecruitmentUUID returns unexpected error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetRecruitMembersWithRecruitmentUUIDs returns unexpected error, err: " + err.Error())
 		return
 	}
 
+	membersForResp := map[string][]*clubproto.RecruitMember{}
+	for _, selectedMember := range selectedMembers {
+		recruitmentUUID := string(selectedMember.RecruitmentUUID)
+		membersForResp[recruitmentUUID] = append(membersForResp[recruitmentUUID], &clubproto.RecruitMember{
+			Grade:  string(selectedMember.Grade),
+			Field:  string(selectedMember.Field),
+			Number: string(selectedMember.Number),
+		})
+	}
+	for _, recruitmentForResp := range recruitmentsForResp {
+		recruitmentForResp.RecruitMembers = append([]*clubproto.RecruitMember{}, membersForResp[recruitmentForResp.RecruitmentUUID]...)
+	}
+
 	access.Commit()
 	resp.Status = http.StatusOK
 	resp.Message = fmt.Sprintf("get recruitments success (len: %d)", len(recruitmentsForResp))
@@ -254,12 +275,16 @@ func (d *_default) GetClubInformWithUUID(ctx context.Context, req *clubproto.Get
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
 	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
+	selectedClub, err := access.GetClubWithClubUUID(ctx, req.ClubUUID)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -277,9 +302,13 @@ func (d *_default) GetClubInformWithUUID(ctx context.Context, req *clubproto.Get
 	}
 
 	spanForDB = d.tracer.StartSpan("GetClubInformWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedInform, err := access.GetClubInformWithClubUUID(string(selectedClub.UUID))
+	selectedInform, err := access.GetClubInformWithClubUUID(ctx, string(selectedClub.UUID))
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedInform", selectedInform), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil {
 		access.Rollback()
@@ -289,9 +318,13 @@ func (d *_default) GetClubInformWithUUID(ctx context.Context, req *clubproto.Get
 	}
 
 	spanForDB = d.tracer.StartSpan("GetClubMembersWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedMembers, err := access.GetClubMembersWithClubUUID(string(selectedClub.UUID))
+	selectedMembers, err := access.GetClubMembersWithClubUUID(ctx, string(selectedClub.UUID))
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
@@ -346,25 +379,26 @@ func (d *_default) GetClubInformsWithUUIDs(ctx context.Context, req *clubproto.G
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 	informsForResp := make([]*clubproto.ClubInform, len(req.ClubUUIDs))
+	clubUUIDs := distinct(req.ClubUUIDs)
 
-	var err error
-	selectedClubs := make([]*model.Club, len(req.ClubUUIDs))
 	spanForDB := d.tracer.StartSpan("GetClubsWithClubUUIDs", opentracing.ChildOf(parentSpan))
-	for index, clubUUID := range req.ClubUUIDs {
-		selectedClub, queryErr := access.GetClubWithClubUUID(clubUUID)
-		if queryErr != nil {
-			err = queryErr
-			break
-		}
-		informsForResp[index] = new(clubproto.ClubInform)
-		informsForResp[index].ClubUUID = string(selectedClub.UUID)
-		informsForResp[index].LeaderUUID = string(selectedClub.LeaderUUID)
-		selectedClubs[index] = selectedClub
-	}
+	selectedClubs, err := access.GetClubsWithClubUUIDs(ctx, clubUUIDs)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClubs", selectedClubs), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
+
+	// club uuid list including not exist uuid is regarded as not found, so empty uuid list is not
+	if err == nil || err == gorm.ErrRecordNotFound {
+		err = nil
+		if len(selectedClubs) != len(clubUUIDs) {
+			err = gorm.ErrRecordNotFound
+		}
+	}
 
 	switch err {
 	case nil:
@@ -377,30 +411,26 @@ func (d *_default) GetClubInformsWithUUIDs(ctx context.Context, req *clubproto.G
 	default:
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubWithClubUUID returns unexpected error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubsWithClubUUIDs returns unexpected error, err: " + err.Error())
 		return
 	}
 
-	selectedInforms := make([]*model.ClubInform, len(req.ClubUUIDs))
 	spanForDB = d.tracer.StartSpan("GetClubInformsWithClubUUIDs", opentracing.ChildOf(parentSpan))
-	for index, clubUUID := range req.ClubUUIDs {
-		selectedInform, queryErr := access.GetClubInformWithClubUUID(clubUUID)
-		if queryErr != nil {
-			err = queryErr
-			break
-		}
-		informsForResp[index].Name = string(selectedInform.Name)
-		informsForResp[index].ClubConcept = string(selectedInform.ClubConcept)
-		informsForResp[index].Introduction = string(selectedInform.Introduction)
-		informsForResp[index].Floor = string(selectedInform.Floor)
-		informsForResp[index].Location = string(selectedInform.Location)
-		informsForResp[index].Field = string(selectedInform.Field)
-		informsForResp[index].Link = string(selectedInform.Link)
-		informsForResp[index].LogoURI = string(selectedInform.LogoURI)
-		selectedInforms[index] = selectedInform
-	}
+	selectedInforms, err := access.GetClubInformsWithClubUUIDs(ctx, clubUUIDs)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedInforms", selectedInforms), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
+
+	// every club must have its inform, so inform not exist is unexpected
+	if err == nil || err == gorm.ErrRecordNotFound {
+		err = nil
+		if len(selectedInforms) != len(clubUUIDs) {
+			err = gorm.ErrRecordNotFound
+		}
+	}
 
 	if err != nil {
 		access.Rollback()
@@ -409,33 +439,53 @@ func (d *_default) GetClubInformsWithUUIDs(ctx context.Context, req *clubproto.G
 		return
 	}
 
-	selectedMembersList := make([][]*model.ClubMember, len(req.ClubUUIDs))
-	spanForDB = d.tracer.StartSpan("GetClubMembersListWithClubUUIDs", opentracing.ChildOf(parentSpan))
-	for index, clubUUID := range req.ClubUUIDs {
-		selectedMembers, queryErr := access.GetClubMembersWithClubUUID(clubUUID)
-		if queryErr == gorm.ErrRecordNotFound {
-			err = queryErr
-		} else if queryErr != nil {
-			err = queryErr
-			break
-		}
-		membersForResp := make([]string, len(selectedMembers))
-		for index, selectedMember := range selectedMembers {
-			membersForResp[index] = string(selectedMember.StudentUUID)
-		}
-		informsForResp[index].MemberUUIDs = membersForResp
-		selectedMembersList[index] = selectedMembers
-	}
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembersList", selectedMembersList), log.Error(err))
+	spanForDB = d.tracer.StartSpan("GetClubMembersWithClubUUIDs", opentracing.ChildOf(parentSpan))
+	selectedMembers, err := access.GetClubMembersWithClubUUIDs(ctx, clubUUIDs)
+	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubMembersListWithClubUUIDs returns unexepcted error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubMembersWithClubUUIDs returns unexepcted error, err: " + err.Error())
 		return
 	}
 
+	clubsByUUID := make(map[string]*model.Club, len(selectedClubs))
+	for _, selectedClub := range selectedClubs {
+		clubsByUUID[string(selectedClub.UUID)] = selectedClub
+	}
+	informsByUUID := make(map[string]*model.ClubInform, len(selectedInforms))
+	for _, selectedInform := range selectedInforms {
+		informsByUUID[string(selectedInform.ClubUUID)] = selectedInform
+	}
+	memberUUIDs := map[string][]string{}
+	for _, selectedMember := range selectedMembers {
+		clubUUID := string(selectedMember.ClubUUID)
+		memberUUIDs[clubUUID] = append(memberUUIDs[clubUUID], string(selectedMember.StudentUUID))
+	}
+
+	for index, clubUUID := range req.ClubUUIDs {
+		selectedClub, selectedInform := clubsByUUID[clubUUID], informsByUUID[clubUUID]
+		informsForResp[index] = &clubproto.ClubInform{
+			ClubUUID:     string(selectedClub.UUID),
+			LeaderUUID:   string(selectedClub.LeaderUUID),
+			Name:         string(selectedInform.Name),
+			ClubConcept:  string(selectedInform.ClubConcept),
+			Introduction: string(selectedInform.Introduction),
+			Floor:        string(selectedInform.Floor),
+			Location:     string(selectedInform.Location),
+			Field:        string(selectedInform.Field),
+			Link:         string(selectedInform.Link),
+			LogoURI:      string(selectedInform.LogoURI),
+			MemberUUIDs:  append([]string{}, memberUUIDs[clubUUID]...),
+		}
+	}
+
 	access.Commit()
 	resp.Status = http.StatusOK
 	resp.Informs = informsForResp
@@ -465,12 +515,16 @@ func (d *_default) GetRecruitmentInformWithUUID(ctx context.Context, req *clubpr
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
 	spanForDB := d.tracer.StartSpan("GetRecruitmentWithRecruitmentUUID", opentracing.ChildOf(parentSpan))
-	selectedRecruitment, err := access.GetRecruitmentWithRecruitmentUUID(req.RecruitmentUUID)
+	selectedRecruitment, err := access.GetRecruitmentWithRecruitmentUUID(ctx, req.RecruitmentUUID)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruitment", selectedRecruitment), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -488,9 +542,13 @@ func (d *_default) GetRecruitmentInformWithUUID(ctx context.Context, req *clubpr
 	}
 
 	spanForDB = d.tracer.StartSpan("GetClubMembersWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedMembers, err := access.GetRecruitMembersWithRecruitmentUUID(req.RecruitmentUUID)
+	selectedMembers, err := access.GetRecruitMembersWithRecruitmentUUID(ctx, req.RecruitmentUUID)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedMembers", selectedMembers), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
@@ -549,12 +607,16 @@ func (d *_default) GetRecruitmentUUIDWithClubUUID(ctx context.Context, req *club
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
 	spanForDB := d.tracer.StartSpan("GetClubWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithClubUUID(req.ClubUUID)
+	selectedClub, err := access.GetClubWithClubUUID(ctx, req.ClubUUID)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -572,9 +634,13 @@ func (d *_default) GetRecruitmentUUIDWithClubUUID(ctx context.Context, req *club
 	}
 
 	spanForDB = d.tracer.StartSpan("GetCurrentRecruitmentWithClubUUID", opentracing.ChildOf(parentSpan))
-	selectedRecruitment, err := access.GetCurrentRecruitmentWithClubUUID(req.ClubUUID)
+	selectedRecruitment, err := access.GetCurrentRecruitmentWithClubUUID(ctx, req.ClubUUID)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruitment", selectedRecruitment), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -621,21 +687,25 @@ func (d *_default) GetRecruitmentUUIDsWithClubUUIDs(ctx context.Context, req *cl
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
-	var err error
-	selectedClubs := make([]*model.Club, len(req.ClubUUIDs))
+	clubUUIDs := distinct(req.ClubUUIDs)
 	spanForDB := d.tracer.StartSpan("GetClubsWithClubUUIDs", opentracing.ChildOf(parentSpan))
-	for index, clubUUID := range req.ClubUUIDs {
-		selectedClub, queryErr := access.GetClubWithClubUUID(clubUUID)
-		if queryErr != nil {
-			err = queryErr
-			break
-		}
-		selectedClubs[index] = selectedClub
-	}
+	selectedClubs, err := access.GetClubsWithClubUUIDs(ctx, clubUUIDs)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClubs", selectedClubs), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
+
+	// club uuid list including not exist uuid is regarded as not found, so empty uuid list is not
+	if err == nil || err == gorm.ErrRecordNotFound {
+		err = nil
+		if len(selectedClubs) != len(clubUUIDs) {
+			err = gorm.ErrRecordNotFound
+		}
+	}
 
 	switch err {
 	case nil:
@@ -656,7 +726,7 @@ func (d *_default) GetRecruitmentUUIDsWithClubUUIDs(ctx context.Context, req *cl
 	selectedRecruits := make([]*model.ClubRecruitment, len(req.ClubUUIDs))
 	spanForDB = d.tracer.StartSpan("GetCurrentRecruitmentsWithClubUUIDs", opentracing.ChildOf(parentSpan))
 	for index, clubUUID := range req.ClubUUIDs {
-		selectedRecruit, queryErr := access.GetCurrentRecruitmentWithClubUUID(clubUUID)
+		selectedRecruit, queryErr := access.GetCurrentRecruitmentWithClubUUID(ctx, clubUUID)
 		if queryErr == gorm.ErrRecordNotFound {
 			err = queryErr
 		} else if queryErr != nil {
@@ -671,6 +741,10 @@ func (d *_default) GetRecruitmentUUIDsWithClubUUIDs(ctx context.Context, req *cl
 	}
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedRecruits", selectedRecruits), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
@@ -708,27 +782,26 @@ func (d *_default) GetAllClubFields(ctx context.Context, req *clubproto.GetAllCl
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
-	spanForDB := d.tracer.StartSpan("GetAllClubInforms", opentracing.ChildOf(parentSpan))
-	allInforms, err := access.GetAllClubInforms()
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("AllInforms", allInforms), log.Error(err))
+	// request message has no filter field in pinned protocol buffer yet, so it is queried without filter
+	spanForDB := d.tracer.StartSpan("GetDistinctClubFields", opentracing.ChildOf(parentSpan))
+	selectedFields, err := access.GetDistinctClubFields(ctx, "", "")
+	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedFields", selectedFields), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetAllClubInforms returns unexpected error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetDistinctClubFields returns unexpected error, err: " + err.Error())
 		return
 	}
 
-	fieldsForResp := make([]string, 0, 5)
-	for _, inform := range allInforms {
-		if contains(fieldsForResp, string(inform.Field)) {
-			continue
-		}
-		fieldsForResp = append(fieldsForResp, string(inform.Field))
-	}
+	fieldsForResp := append([]string{}, selectedFields...)
 
 	access.Commit()
 	resp.Status = http.StatusOK
@@ -759,23 +832,28 @@ func (d *_default) GetTotalCountOfClubs(ctx context.Context, req *clubproto.GetT
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
-	spanForDB := d.tracer.StartSpan("GetAllClubInforms", opentracing.ChildOf(parentSpan))
-	allInforms, err := access.GetAllClubInforms()
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("AllInforms", allInforms), log.Error(err))
+	// request message has no filter field in pinned protocol buffer yet, so it is queried without filter
+	spanForDB := d.tracer.StartSpan("GetClubInformsCount", opentracing.ChildOf(parentSpan))
+	informsCount, err := access.GetClubInformsCount(ctx, "", "", "")
+	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int64("InformsCount", informsCount), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
-	if err != nil && err != gorm.ErrRecordNotFound {
+	if err != nil {
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetAllClubInforms returns unexpected error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetClubInformsCount returns unexpected error, err: " + err.Error())
 		return
 	}
 
 	access.Commit()
 	resp.Status = http.StatusOK
-	resp.Count = int64(len(allInforms))
+	resp.Count = informsCount
 	resp.Message = fmt.Sprintf("get total count of club success")
 	return
 }
@@ -802,23 +880,28 @@ func (d *_default) GetTotalCountOfCurrentRecruitments(ctx context.Context, req *
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
-	spanForDB := d.tracer.StartSpan("GetAllCurrentRecruitments", opentracing.ChildOf(parentSpan))
-	allRecruitments, err := access.GetAllCurrentRecruitments()
-	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("AllRecruitments", allRecruitments), log.Error(err))
+	// request message has no filter field in pinned protocol buffer yet, so it is queried without filter
+	spanForDB := d.tracer.StartSpan("GetCurrentRecruitmentsCount", opentracing.ChildOf(parentSpan))
+	recruitmentsCount, err := access.GetCurrentRecruitmentsCount(ctx, "", "")
+	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Int64("RecruitmentsCount", recruitmentsCount), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
-	if err != nil && err != gorm.ErrRecordNotFound {
+	if err != nil {
 		access.Rollback()
 		resp.Status = http.StatusInternalServerError
-		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetAllCurrentRecruitments returns unexpected error, err: " + err.Error())
+		resp.Message = fmt.Sprintf(internalServerMessageFormat, "GetCurrentRecruitmentsCount returns unexpected error, err: " + err.Error())
 		return
 	}
 
 	access.Commit()
 	resp.Status = http.StatusOK
-	resp.Count = int64(len(allRecruitments))
+	resp.Count = recruitmentsCount
 	resp.Message = fmt.Sprintf("get total count of current recruitment success")
 	return
 }
@@ -845,12 +928,16 @@ func (d *_default) GetClubUUIDWithLeaderUUID(ctx context.Context, req *clubproto
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
 	spanForDB := d.tracer.StartSpan("GetClubWithLeaderUUID", opentracing.ChildOf(parentSpan))
-	selectedClub, err := access.GetClubWithLeaderUUID(req.LeaderUUID)
+	selectedClub, err := access.GetClubWithLeaderUUID(ctx, req.LeaderUUID)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("SelectedClub", selectedClub), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	switch err {
 	case nil:
@@ -899,12 +986,16 @@ func (d *_default) GetClubUUIDsWithFloor(ctx context.Context, req *clubproto.Get
 	reqID := ctx.Value("X-Request-Id").(string)
 	parentSpan := ctx.Value("Span-Context").(jaeger.SpanContext)
 
-	access := d.accessManage.BeginTx()
+	access := d.accessManage.BeginReadOnlyTx()
 
 	spanForDB := d.tracer.StartSpan("GetClubInformsWithFloor", opentracing.ChildOf(parentSpan))
-	selectedInforms, err := access.GetClubInformsWithFloor(req.Floor)
+	selectedInforms, err := access.GetClubInformsWithFloor(ctx, req.Floor)
 	spanForDB.SetTag("X-Request-Id", reqID).LogFields(log.Object("selectedInforms", selectedInforms), log.Error(err))
 	spanForDB.Finish()
+	if d.handleDBTimeout(err, &resp.Status, &resp.Message) {
+		access.Rollback()
+		return
+	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 		access.Rollback()
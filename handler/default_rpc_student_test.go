@@ -5,6 +5,7 @@ import (
 	"club/model"
 	clubproto "club/proto/golang/club"
 	code "club/utils/code/golang"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -1406,6 +1407,24 @@ func Test_Default_GetRecruitmentUUIDWithClubUUID(t *testing.T) {
 				"Rollback":            {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
+		}, { // GetClubWithClubUUID is canceled by deadline of request -> Request Timeout
+			UUID:     "student-111111111111",
+			ClubUUID: "club-111111111111",
+			ExpectedMethods: map[test.Method]test.Returns{
+				"BeginTx":             {},
+				"GetClubWithClubUUID": {&model.Club{}, context.DeadlineExceeded},
+				"Rollback":            {&gorm.DB{}},
+			},
+			ExpectedStatus: http.StatusRequestTimeout,
+		}, { // GetClubWithClubUUID is canceled by client, not deadline -> Internal Server Error
+			UUID:     "student-111111111111",
+			ClubUUID: "club-111111111111",
+			ExpectedMethods: map[test.Method]test.Returns{
+				"BeginTx":             {},
+				"GetClubWithClubUUID": {&model.Club{}, context.Canceled},
+				"Rollback":            {&gorm.DB{}},
+			},
+			ExpectedStatus: http.StatusInternalServerError,
 		}, { // GetCurrentRecruitmentWithClubUUID returns not found error
 			UUID:     "admin-111111111111",
 			ClubUUID: "club-111111111111",
@@ -1594,38 +1613,7 @@ func Test_Default_GetAllClubFields(t *testing.T) {
 			UUID: "student-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetAllClubInforms": {[]*model.ClubInform{{
-					ClubUUID: "club-111111111111",
-					Name:     "DMS",
-					Field:    "SW 개발",
-					Location: "2-1반 교실",
-					Floor:    "3",
-					LogoURI:  "logo.com/club-111111111111",
-				}, {
-					ClubUUID:     "club-222222222222",
-					Name:         "SMS",
-					ClubConcept:  "DMS의 소속부서 SMS 입니다!",
-					Introduction: "School Management System 서비스를 개발 및 운영합니다",
-					Link:         "facebook.com/DMS-SMS",
-					Field:        "SW 개발",
-					Location:     "2-2반 교실",
-					Floor:        "3",
-					LogoURI:      "logo.com/club-222222222222",
-				}, {
-					ClubUUID: "club-333333333333",
-					Name:     "ESC",
-					Field:    "임베디드 SW 개발",
-					Location: "세미나실 어딘가",
-					Floor:    "3",
-					LogoURI:  "logo.com/club-333333333333",
-				}, {
-					ClubUUID: "club-444444444444",
-					Name:     "PMS",
-					Field:    "SW 개발",
-					Location: "2-3반 교실",
-					Floor:    "3",
-					LogoURI:  "logo.com/club-444444444444",
-				}}, nil},
+				"GetDistinctClubFields": {[]string{"SW 개발", "임베디드 SW 개발"}, nil},
 				"Commit": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusOK,
@@ -1650,21 +1638,21 @@ func Test_Default_GetAllClubFields(t *testing.T) {
 			UUID:            "parent-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{},
 			ExpectedStatus:  http.StatusForbidden,
-		}, { // GetAllClubInforms returns not found error
+		}, { // GetDistinctClubFields returns not found error
 			UUID: "admin-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":           {},
-				"GetAllClubInforms": {[]*model.ClubInform{}, gorm.ErrRecordNotFound},
-				"Commit":            {&gorm.DB{}},
+				"BeginTx":               {},
+				"GetDistinctClubFields": {[]string{}, gorm.ErrRecordNotFound},
+				"Commit":                {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusOK,
 			ExpectedFields: []string{},
-		}, { // GetAllClubInforms returns unexpected error
+		}, { // GetDistinctClubFields returns unexpected error
 			UUID: "admin-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":           {},
-				"GetAllClubInforms": {[]*model.ClubInform{}, errors.New("unexpected error")},
-				"Rollback":          {&gorm.DB{}},
+				"BeginTx":               {},
+				"GetDistinctClubFields": {[]string{}, errors.New("unexpected error")},
+				"Rollback":              {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		},
@@ -1698,38 +1686,7 @@ func Test_Default_GetTotalCountOfClubs(t *testing.T) {
 			UUID: "student-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetAllClubInforms": {[]*model.ClubInform{{
-					ClubUUID: "club-111111111111",
-					Name:     "DMS",
-					Field:    "SW 개발",
-					Location: "2-1반 교실",
-					Floor:    "3",
-					LogoURI:  "logo.com/club-111111111111",
-				}, {
-					ClubUUID:     "club-222222222222",
-					Name:         "SMS",
-					ClubConcept:  "DMS의 소속부서 SMS 입니다!",
-					Introduction: "School Management System 서비스를 개발 및 운영합니다",
-					Link:         "facebook.com/DMS-SMS",
-					Field:        "SW 개발",
-					Location:     "2-2반 교실",
-					Floor:        "3",
-					LogoURI:      "logo.com/club-222222222222",
-				}, {
-					ClubUUID: "club-333333333333",
-					Name:     "ESC",
-					Field:    "임베디드 SW 개발",
-					Location: "세미나실 어딘가",
-					Floor:    "3",
-					LogoURI:  "logo.com/club-333333333333",
-				}, {
-					ClubUUID: "club-444444444444",
-					Name:     "PMS",
-					Field:    "SW 개발",
-					Location: "2-3반 교실",
-					Floor:    "3",
-					LogoURI:  "logo.com/club-444444444444",
-				}}, nil},
+				"GetClubInformsCount": {int64(4), nil},
 				"Commit": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusOK,
@@ -1754,21 +1711,21 @@ func Test_Default_GetTotalCountOfClubs(t *testing.T) {
 			UUID:            "parent-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{},
 			ExpectedStatus:  http.StatusForbidden,
-		}, { // GetAllClubInforms returns not found error
+		}, { // GetClubInformsCount returns zero count
 			UUID: "admin-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":           {},
-				"GetAllClubInforms": {[]*model.ClubInform{}, gorm.ErrRecordNotFound},
-				"Commit":            {&gorm.DB{}},
+				"BeginTx":             {},
+				"GetClubInformsCount": {int64(0), nil},
+				"Commit":              {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusOK,
 			ExpectedCount:  0,
-		}, { // GetAllClubInforms returns unexpected error
+		}, { // GetClubInformsCount returns unexpected error
 			UUID: "admin-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":           {},
-				"GetAllClubInforms": {[]*model.ClubInform{}, errors.New("unexpected error")},
-				"Rollback":          {&gorm.DB{}},
+				"BeginTx":             {},
+				"GetClubInformsCount": {int64(0), errors.New("unexpected error")},
+				"Rollback":            {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		},
@@ -1797,34 +1754,12 @@ func Test_Default_GetTotalCountOfClubs(t *testing.T) {
 }
 
 func Test_Default_GetTotalCountOfCurrentRecruitments(t *testing.T) {
-	now := time.Now()
-	startTime := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-	endTime := startTime.Add(time.Hour * 24 * 7)
-
 	tests := []test.GetTotalCountOfCurrentRecruitmentsCase{
 		{ // success case
 			UUID: "student-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
 				"BeginTx": {},
-				"GetAllCurrentRecruitments": {[]*model.ClubRecruitment{{
-					UUID:           "recruitment-222222222222",
-					ClubUUID:       "club-111111111111",
-					RecruitConcept: "두 번째 공채",
-					StartPeriod:    model.StartPeriod(startTime),
-					EndPeriod:      model.EndPeriod(endTime),
-				}, {
-					UUID:           "recruitment-555555555555",
-					ClubUUID:       "club-333333333333",
-					RecruitConcept: "첫 번째 상시 채용",
-				}, {
-					UUID:           "recruitment-666666666666",
-					ClubUUID:       "club-555555555555",
-					RecruitConcept: "어서 오십쇼~",
-				}, {
-					UUID:           "recruitment-888888888888",
-					ClubUUID:       "club-666666666666",
-					RecruitConcept: "선배들이 착합니다~",
-				}}, nil},
+				"GetCurrentRecruitmentsCount": {int64(4), nil},
 				"Commit": {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusOK,
@@ -1849,21 +1784,21 @@ func Test_Default_GetTotalCountOfCurrentRecruitments(t *testing.T) {
 			UUID:            "parent-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{},
 			ExpectedStatus:  http.StatusForbidden,
-		}, { // GetAllCurrentRecruitments returns not found error
+		}, { // GetCurrentRecruitmentsCount returns zero count
 			UUID: "admin-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":                   {},
-				"GetAllCurrentRecruitments": {[]*model.ClubRecruitment{}, gorm.ErrRecordNotFound},
-				"Commit":                    {&gorm.DB{}},
+				"BeginTx":                     {},
+				"GetCurrentRecruitmentsCount": {int64(0), nil},
+				"Commit":                      {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusOK,
 			ExpectedCount:  0,
-		}, { // GetAllCurrentRecruitments returns unexpected error
+		}, { // GetCurrentRecruitmentsCount returns unexpected error
 			UUID: "admin-111111111111",
 			ExpectedMethods: map[test.Method]test.Returns{
-				"BeginTx":                   {},
-				"GetAllCurrentRecruitments": {[]*model.ClubRecruitment{}, errors.New("unexpected error")},
-				"Rollback":                  {&gorm.DB{}},
+				"BeginTx":                     {},
+				"GetCurrentRecruitmentsCount": {int64(0), errors.New("unexpected error")},
+				"Rollback":                    {&gorm.DB{}},
 			},
 			ExpectedStatus: http.StatusInternalServerError,
 		},
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"club/db"
 	"context"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/micro/go-micro/v2/metadata"
 	"github.com/uber/jaeger-client-go"
+	"net/http"
 	"regexp"
+	"strconv"
 )
 
 const (
@@ -18,6 +23,18 @@ const (
 	serviceUnavailableMessageFormat = "service unavailable (reason: %s)"
 )
 
+// feature flag to check version of club inform & recruitment in edit, rolled out per editor uuid (req.UUID)
+const flagVersionCheck = "version-check"
+
+// codes of version conflict, declared here until they are added in code package of utils module
+const (
+	clubInformVersionConflict int32 = -4091
+	recruitmentVersionConflict int32 = -4092
+)
+
+// error returned from unit of work in runInTx to roll back transaction, if there is no error to return
+var errTxAborted = errors.New("transaction is aborted by handler")
+
 var (
 	adminUUIDRegex = regexp.MustCompile("^admin-\\d{12}")
 	studentUUIDRegex = regexp.MustCompile("^student-\\d{12}")
@@ -64,13 +81,26 @@ func (_ _default) getContextFromMetadata(ctx context.Context) (parsedCtx context
 	proxyAuthenticated = true
 	reason = ""
 
-	parsedCtx = context.Background()
-	parsedCtx = context.WithValue(parsedCtx, "X-Request-Id", reqID)
+	// keep metadata of ctx, so that header can be used in routing of consul agent
+	parsedCtx = context.WithValue(ctx, "X-Request-Id", reqID)
 	parsedCtx = context.WithValue(parsedCtx, "Span-Context", parentSpan)
 
 	if cUUID, ok := md.Get("ClubUUID"); ok { parsedCtx = context.WithValue(parsedCtx, "ClubUUID", cUUID) }
 	if cUUID, ok := md.Get("RecruitmentUUID"); ok { parsedCtx = context.WithValue(parsedCtx, "RecruitmentUUID", cUUID) }
+	if version, ok := md.Get("Version"); ok { parsedCtx = context.WithValue(parsedCtx, "Version", version) }
+
+	return
+}
 
+// get version of row read by editor, passed with metadata until request message has version field
+// 0 is returned if version doesn't exist in metadata, so that version of row is not checked in update
+func versionFromContext(ctx context.Context) (version uint, err error) {
+	versionString, ok := ctx.Value("Version").(string)
+	if !ok || versionString == "" {
+		return
+	}
+	parsed, err := strconv.ParseUint(versionString, 10, 32)
+	version = uint(parsed)
 	return
 }
 
@@ -82,3 +112,58 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// check if feature flag is on for caller with uuid (req.UUID)
+func (d *_default) isFeatureEnabled(name, uuid string) bool {
+	return d.featureFlags.IsEnabled(name, uuid)
+}
+
+// check if DB query fails because deadline of RPC request is exceeded, canceled request is not regarded as time out
+func isTimeoutError(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// write request timeout response if DB query fails with deadline of RPC request, returns true if response is written
+func (d *_default) handleDBTimeout(err error, status *uint32, message *string) bool {
+	if !isTimeoutError(err) {
+		return false
+	}
+	*status = http.StatusRequestTimeout
+	*message = fmt.Sprintf(requestTimeoutMessageFormat, "db query is canceled by deadline of request, err: " + err.Error())
+	return true
+}
+
+// run fn in transaction, which is run again by RunInTx if it fails with retryable error like deadlock or lock wait timeout
+// fn writes response & returns abortTx to roll back, response is overwritten to internal server error if commit fails
+// returns true if transaction is committed, side effect out of transaction (ex. file upload) must be run after that
+func (d *_default) runInTx(ctx context.Context, status *uint32, message *string, fn func(access db.Accessor) error) (committed bool) {
+	var fnErr error
+	err := d.accessManage.RunInTx(ctx, func(access db.Accessor) error {
+		fnErr = fn(access)
+		return fnErr
+	})
+	if err != nil && fnErr == nil {
+		*status = http.StatusInternalServerError
+		*message = fmt.Sprintf(internalServerMessageFormat, "unable to commit transaction, err: " + err.Error())
+	}
+	return err == nil
+}
+
+// returns error to roll back transaction in runInTx, err is returned as it is so that retryable error is run again
+func abortTx(err error) error {
+	if err == nil {
+		return errTxAborted
+	}
+	return err
+}
+
+// returns slice removed duplicated items, order of first appearance is kept
+func distinct(slice []string) []string {
+	distinctSlice := make([]string, 0, len(slice))
+	for _, element := range slice {
+		if !contains(distinctSlice, element) {
+			distinctSlice = append(distinctSlice, element)
+		}
+	}
+	return distinctSlice
+}
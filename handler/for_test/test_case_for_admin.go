@@ -8,7 +8,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/micro/go-micro/v2/metadata"
-	"github.com/stretchr/testify/mock"
+	mockpkg "github.com/stretchr/testify/mock"
 	"log"
 )
 
@@ -59,13 +59,13 @@ func (test *CreateNewClubCase) ChangeEmptyReplaceValueToEmptyValue() {
 	if test.SpanContextString == EmptyReplaceValueForString        { test.SpanContextString = "" }
 }
 
-func (test *CreateNewClubCase) OnExpectMethodsTo(mock *mock.Mock) {
+func (test *CreateNewClubCase) OnExpectMethodsTo(mock *mockpkg.Mock) {
 	for method, returns := range test.ExpectedMethods {
 		test.onMethod(mock, method, returns)
 	}
 }
 
-func (test *CreateNewClubCase) onMethod(mock *mock.Mock, method Method, returns Returns) {
+func (test *CreateNewClubCase) onMethod(mock *mockpkg.Mock, method Method, returns Returns) {
 	switch method {
 	case "CreateClub":
 		const indexClubModel = 0
@@ -111,8 +111,8 @@ func (test *CreateNewClubCase) onMethod(mock *mock.Mock, method Method, returns
 			StudentUUIDs: test.MemberUUIDs,
 		}).Return(returns...)
 
-	case "GetNextServiceNode":
-		mock.On(string(method), topic.AuthServiceName).Return(returns...)
+	case "GetNextServiceNodeWithContext":
+		mock.On(string(method), mockpkg.Anything, topic.AuthServiceName).Return(returns...)
 
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
@@ -45,8 +45,8 @@ func (test *AddClubMemberCase) onMethod(mock *mockpkg.Mock, method Method, retur
 	switch method {
 	case "GetClubWithClubUUID":
 		mock.On(string(method), test.ClubUUID).Return(returns...)
-	case "GetNextServiceNode":
-		mock.On(string(method), topic.AuthServiceName).Return(returns...)
+	case "GetNextServiceNodeWithContext":
+		mock.On(string(method), mockpkg.Anything, topic.AuthServiceName).Return(returns...)
 	case "GetStudentInformWithUUID":
 		mock.On(string(method), &authproto.GetStudentInformWithUUIDRequest{
 			UUID:        test.UUID,
@@ -212,6 +212,7 @@ type ModifyClubInformCase struct {
 	Introduction      string
 	Link              string
 	Logo              []byte
+	Version           uint32
 	XRequestID        string
 	SpanContextString string
 	ExpectedMethods   map[Method]Returns
@@ -250,7 +251,7 @@ func (test *ModifyClubInformCase) onMethod(mock *mockpkg.Mock, method Method, re
 	case "GetClubWithClubUUID":
 		mock.On(string(method), test.ClubUUID).Return(returns...)
 	case "ModifyClubInform":
-		mock.On(string(method), test.ClubUUID, &model.ClubInform{
+		mock.On(string(method), test.ClubUUID, uint(test.Version), &model.ClubInform{
 			ClubConcept:  model.ClubConcept(test.ClubConcept),
 			Introduction: model.Introduction(test.Introduction),
 			Link:         model.Link(test.Link),
@@ -279,6 +280,9 @@ func (test *ModifyClubInformCase) GetMetadataContext() (ctx context.Context) {
 	ctx = context.Background()
 	ctx = metadata.Set(ctx, "X-Request-Id", test.XRequestID)
 	ctx = metadata.Set(ctx, "Span-Context", test.SpanContextString)
+	if test.Version != 0 {
+		ctx = metadata.Set(ctx, "Version", strconv.Itoa(int(test.Version)))
+	}
 	return
 }
 
@@ -309,7 +313,7 @@ func (test *DeleteClubWithUUIDCase) OnExpectMethodsTo(mock *mockpkg.Mock) {
 
 func (test *DeleteClubWithUUIDCase) onMethod(mock *mockpkg.Mock, method Method, returns Returns) {
 	switch method {
-	case "GetClubWithClubUUID":
+	case "GetClubWithClubUUIDForUpdate":
 		mock.On(string(method), test.ClubUUID).Return(returns...)
 	case "GetCurrentRecruitmentWithClubUUID":
 		mock.On(string(method), test.ClubUUID).Return(returns...)
@@ -390,7 +394,7 @@ func (test *RegisterRecruitmentCase) onMethod(mock *mockpkg.Mock, method Method,
 	switch method {
 	case "GetRecruitmentWithRecruitmentUUID":
 		mock.On(string(method), test.RecruitmentUUID).Return(returns...)
-	case "GetClubWithClubUUID":
+	case "GetClubWithClubUUIDForUpdate":
 		mock.On(string(method), test.ClubUUID).Return(returns...)
 	case "GetCurrentRecruitmentWithClubUUID":
 		mock.On(string(method), test.ClubUUID).Return(returns...)
@@ -478,6 +482,7 @@ type ModifyRecruitmentCase struct {
 	UUID, RecruitmentUUID string
 	RecruitmentConcept    string
 	RecruitMembers        []*clubproto.RecruitMember
+	Version               uint32
 	XRequestID            string
 	SpanContextString     string
 	ExpectedMethods       map[Method]Returns
@@ -510,7 +515,7 @@ func (test *ModifyRecruitmentCase) onMethod(mock *mockpkg.Mock, method Method, r
 			return regexp.MustCompile("^club-\\d{12}").MatchString(clubUUID)
 		})).Return(returns...)
 	case "ModifyRecruitment":
-		mock.On(string(method), test.RecruitmentUUID, &model.ClubRecruitment{
+		mock.On(string(method), test.RecruitmentUUID, uint(test.Version), &model.ClubRecruitment{
 			RecruitConcept: model.RecruitConcept(test.RecruitmentConcept),
 		}).Return(returns...)
 	case "DeleteAllRecruitMember":
@@ -558,6 +563,9 @@ func (test *ModifyRecruitmentCase) GetMetadataContext() (ctx context.Context) {
 	ctx = metadata.Set(ctx, "X-Request-Id", test.XRequestID)
 	ctx = metadata.Set(ctx, "Span-Context", test.SpanContextString)
 	ctx = metadata.Set(ctx, "RecruitmentUUID", test.RecruitmentUUID)
+	if test.Version != 0 {
+		ctx = metadata.Set(ctx, "Version", strconv.Itoa(int(test.Version)))
+	}
 	return
 }
 
@@ -55,23 +55,23 @@ func (test *GetClubsSortByUpdateTimeCase) onMethod(mock *mock.Mock, method Metho
 		const indexForClubs = 0
 		const indexForError = 1
 		informs := test.ExpectedMethods["GetClubInformsSortByUpdateTime"][indexForClubInforms].([]*model.ClubInform)
+		clubUUIDs := make([]string, len(informs))
 		for index, inform := range informs {
-			mock.On("GetClubWithClubUUID", string(inform.ClubUUID)).Return(returns[indexForClubs].([]*model.Club)[index], returns[indexForError])
-			if returns[indexForError] != nil {
-				break
-			}
+			clubUUIDs[index] = string(inform.ClubUUID)
 		}
+		clubUUIDs, firstIndexes := distinctWithFirstIndexes(clubUUIDs)
+		mock.On(string(method), clubUUIDs).Return(clubsInIndexes(returns[indexForClubs].([]*model.Club), firstIndexes), returns[indexForError])
 	case "GetClubMembersWithClubUUIDs":
 		const indexForClubInforms = 0
 		const indexForCLubMembers = 0
 		const indexForError = 1
 		informs := test.ExpectedMethods["GetClubInformsSortByUpdateTime"][indexForClubInforms].([]*model.ClubInform)
+		clubUUIDs := make([]string, len(informs))
 		for index, inform := range informs {
-			mock.On("GetClubMembersWithClubUUID", string(inform.ClubUUID)).Return(returns[indexForCLubMembers].([][]*model.ClubMember)[index], returns[indexForError])
-			if returns[indexForError] != nil && returns[indexForError] != gorm.ErrRecordNotFound {
-				break
-			}
+			clubUUIDs[index] = string(inform.ClubUUID)
 		}
+		clubUUIDs, firstIndexes := distinctWithFirstIndexes(clubUUIDs)
+		mock.On(string(method), clubUUIDs).Return(clubMembersInIndexes(returns[indexForCLubMembers].([][]*model.ClubMember), firstIndexes), returns[indexForError])
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
 	case "Commit":
@@ -143,12 +143,12 @@ func (test *GetRecruitmentsSortByCreateTimeCase) onMethod(mock *mock.Mock, metho
 		const indexForRecruitMembersList = 0
 		const indexForError = 1
 		recruitments := test.ExpectedMethods["GetCurrentRecruitmentsSortByCreateTime"][indexForRecruitments].([]*model.ClubRecruitment)
+		recruitmentUUIDs := make([]string, len(recruitments))
 		for index, recruitment := range recruitments {
-			mock.On("GetRecruitMembersWithRecruitmentUUID", string(recruitment.UUID)).Return(returns[indexForRecruitMembersList].([][]*model.RecruitMember)[index], returns[indexForError])
-			if returns[indexForError] != nil && returns[indexForError] != gorm.ErrRecordNotFound {
-				break
-			}
+			recruitmentUUIDs[index] = string(recruitment.UUID)
 		}
+		recruitmentUUIDs, firstIndexes := distinctWithFirstIndexes(recruitmentUUIDs)
+		mock.On(string(method), recruitmentUUIDs).Return(recruitMembersInIndexes(returns[indexForRecruitMembersList].([][]*model.RecruitMember), firstIndexes), returns[indexForError])
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
 	case "Commit":
@@ -270,30 +270,18 @@ func (test *GetClubInformsWithUUIDsCase) onMethod(mock *mock.Mock, method Method
 	case "GetClubWithClubUUIDs":
 		const indexForClubs = 0
 		const indexForError = 1
-		for index, clubUUID := range test.ClubUUIDs {
-			mock.On("GetClubWithClubUUID", clubUUID).Return(returns[indexForClubs].([]*model.Club)[index], returns[indexForError])
-			if returns[indexForError] != nil {
-				break
-			}
-		}
+		clubUUIDs, firstIndexes := distinctWithFirstIndexes(test.ClubUUIDs)
+		mock.On("GetClubsWithClubUUIDs", clubUUIDs).Return(clubsInIndexes(returns[indexForClubs].([]*model.Club), firstIndexes), returns[indexForError])
 	case "GetClubInformWithClubUUIDs":
 		const indexForClubInforms = 0
 		const indexForError = 1
-		for index, clubUUID := range test.ClubUUIDs {
-			mock.On("GetClubInformWithClubUUID", clubUUID).Return(returns[indexForClubInforms].([]*model.ClubInform)[index], returns[indexForError])
-			if returns[indexForError] != nil {
-				break
-			}
-		}
+		clubUUIDs, firstIndexes := distinctWithFirstIndexes(test.ClubUUIDs)
+		mock.On("GetClubInformsWithClubUUIDs", clubUUIDs).Return(clubInformsInIndexes(returns[indexForClubInforms].([]*model.ClubInform), firstIndexes), returns[indexForError])
 	case "GetClubMembersWithClubUUIDs":
 		const indexForClubMembersList = 0
 		const indexForError = 1
-		for index, clubUUID := range test.ClubUUIDs {
-			mock.On("GetClubMembersWithClubUUID", clubUUID).Return(returns[indexForClubMembersList].([][]*model.ClubMember)[index], returns[indexForError])
-			if returns[indexForError] != nil && returns[indexForError] != gorm.ErrRecordNotFound {
-				break
-			}
-		}
+		clubUUIDs, firstIndexes := distinctWithFirstIndexes(test.ClubUUIDs)
+		mock.On(string(method), clubUUIDs).Return(clubMembersInIndexes(returns[indexForClubMembersList].([][]*model.ClubMember), firstIndexes), returns[indexForError])
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
 	case "Commit":
@@ -469,12 +457,8 @@ func (test *GetRecruitmentUUIDsWithClubUUIDsCase) onMethod(mock *mock.Mock, meth
 	case "GetClubsWithClubUUIDs":
 		const indexForClubs = 0
 		const indexForError = 1
-		for index, clubUUID := range test.ClubUUIDs {
-			mock.On("GetClubWithClubUUID", clubUUID).Return(returns[indexForClubs].([]*model.Club)[index], returns[indexForError])
-			if returns[indexForError] != nil {
-				break
-			}
-		}
+		clubUUIDs, firstIndexes := distinctWithFirstIndexes(test.ClubUUIDs)
+		mock.On(string(method), clubUUIDs).Return(clubsInIndexes(returns[indexForClubs].([]*model.Club), firstIndexes), returns[indexForError])
 	case "GetCurrentRecruitmentsWithClubUUIDs":
 		const indexForRecruitments = 0
 		const indexForError = 1
@@ -535,8 +519,8 @@ func (test *GetAllClubFieldsCase) OnExpectMethodsTo(mock *mock.Mock) {
 
 func (test *GetAllClubFieldsCase) onMethod(mock *mock.Mock, method Method, returns Returns) {
 	switch method {
-	case "GetAllClubInforms":
-		mock.On(string(method)).Return(returns...)
+	case "GetDistinctClubFields":
+		mock.On(string(method), "", "").Return(returns...)
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
 	case "Commit":
@@ -587,8 +571,8 @@ func (test *GetTotalCountOfClubsCase) OnExpectMethodsTo(mock *mock.Mock) {
 
 func (test *GetTotalCountOfClubsCase) onMethod(mock *mock.Mock, method Method, returns Returns) {
 	switch method {
-	case "GetAllClubInforms":
-		mock.On(string(method)).Return(returns...)
+	case "GetClubInformsCount":
+		mock.On(string(method), "", "", "").Return(returns...)
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
 	case "Commit":
@@ -639,8 +623,8 @@ func (test *GetTotalCountOfCurrentRecruitmentsCase) OnExpectMethodsTo(mock *mock
 
 func (test *GetTotalCountOfCurrentRecruitmentsCase) onMethod(mock *mock.Mock, method Method, returns Returns) {
 	switch method {
-	case "GetAllCurrentRecruitments":
-		mock.On(string(method)).Return(returns...)
+	case "GetCurrentRecruitmentsCount":
+		mock.On(string(method), "", "").Return(returns...)
 	case "BeginTx":
 		mock.On(string(method)).Return(returns...)
 	case "Commit":
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"club/model"
 	"club/tool/random"
 	"gorm.io/gorm"
 	"time"
@@ -15,3 +16,63 @@ func createGormModelOnCurrentTime() gorm.Model {
 		DeletedAt: gorm.DeletedAt{},
 	}
 }
+
+// returns uuid list removed duplicated uuid & index of first appearance of each uuid in original list
+// it is used to convert returns per uuid declared in test case into returns of batch query with IN condition
+func distinctWithFirstIndexes(uuids []string) (distinctUUIDs []string, firstIndexes []int) {
+	distinctUUIDs = []string{}
+	appeared := map[string]bool{}
+	for index, uuid := range uuids {
+		if appeared[uuid] {
+			continue
+		}
+		appeared[uuid] = true
+		distinctUUIDs = append(distinctUUIDs, uuid)
+		firstIndexes = append(firstIndexes, index)
+	}
+	return
+}
+
+// returns clubs in first indexes, empty club declared as placeholder of not exist club is excluded
+func clubsInIndexes(clubs []*model.Club, indexes []int) []*model.Club {
+	selected := []*model.Club{}
+	for _, index := range indexes {
+		if index < len(clubs) && clubs[index] != nil && clubs[index].UUID != "" {
+			selected = append(selected, clubs[index])
+		}
+	}
+	return selected
+}
+
+// returns club informs in first indexes, empty inform declared as placeholder of not exist inform is excluded
+func clubInformsInIndexes(informs []*model.ClubInform, indexes []int) []*model.ClubInform {
+	selected := []*model.ClubInform{}
+	for _, index := range indexes {
+		if index < len(informs) && informs[index] != nil && informs[index].ClubUUID != "" {
+			selected = append(selected, informs[index])
+		}
+	}
+	return selected
+}
+
+// returns flattened club members in first indexes
+func clubMembersInIndexes(membersList [][]*model.ClubMember, indexes []int) []*model.ClubMember {
+	selected := []*model.ClubMember{}
+	for _, index := range indexes {
+		if index < len(membersList) {
+			selected = append(selected, membersList[index]...)
+		}
+	}
+	return selected
+}
+
+// returns flattened recruit members in first indexes
+func recruitMembersInIndexes(membersList [][]*model.RecruitMember, indexes []int) []*model.RecruitMember {
+	selected := []*model.RecruitMember{}
+	for _, index := range indexes {
+		if index < len(membersList) {
+			selected = append(selected, membersList[index]...)
+		}
+	}
+	return selected
+}
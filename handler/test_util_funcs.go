@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"club/config"
 	consulagent "club/consul/agent"
 	"club/db"
 	"club/db/access"
@@ -22,10 +23,15 @@ func newDefaultMockHandler(mock *mock.Mock) *_default {
 	mockConsulAgent := consulagent.Mock(mock)
 	mockAuthStudent := authproto.MockAuthStudentService(mock)
 
+	// handler paths behind feature flag are tested as flag is on, except test for flag itself
+	featureFlags := config.Flags()
+	featureFlags.Override(flagVersionCheck, true)
+
 	return Default(
 		AccessManager(mockAccessManage),
 		Tracer(exampleTracerForRPCService),
 		ConsulAgent(mockConsulAgent),
 		AuthStudent(mockAuthStudent),
+		FeatureFlags(featureFlags),
 	)
 }
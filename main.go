@@ -1,6 +1,8 @@
 package main
 
 import (
+	authcli "club/client/auth"
+	"club/config"
 	"club/consul"
 	consulagent "club/consul/agent"
 	"club/db"
@@ -8,8 +10,11 @@ import (
 	"club/handler"
 	authproto "club/proto/golang/auth"
 	clubproto "club/proto/golang/club"
+	"club/storage"
 	"club/subscriber"
 	"club/tool/closure"
+	"club/tool/graceful"
+	"club/tool/healthcheck"
 	"club/tool/network"
 	topic "club/utils/topic/golang"
 	"fmt"
@@ -18,7 +23,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/hashicorp/consul/api"
+	"github.com/micro/cli/v2"
 	"github.com/micro/go-micro/v2"
 	"github.com/micro/go-micro/v2/client"
 	grpccli "github.com/micro/go-micro/v2/client/grpc"
@@ -29,123 +36,242 @@ import (
 	jaegercfg "github.com/uber/jaeger-client-go/config"
 	"log"
 	"os"
+	"path/filepath"
 	"time"
 )
 
+// feature flag to listen consul change message in SQS, instead of consul watch
+const flagConsulChangeListener = "consul-change-sqs-listener"
+
 func main() {
 	// create service
-	port := network.GetRandomPortNotInUsedWithRange(10101, 10200)
+	rpcDrainer := graceful.RPCDrainer()
 	service := micro.NewService(
 		micro.Name(topic.ClubServiceName),
 		micro.Version("1.0.5"),
 		micro.Transport(grpc.NewTransport()),
-		micro.Address(fmt.Sprintf(":%d", port)),
+		micro.WrapHandler(rpcDrainer.HandlerWrapper()),
+		micro.Flags(config.CLIFlags()...),
 	)
 	srvID := fmt.Sprintf("%s-%s", service.Server().Options().Name, service.Server().Options().Id)
+	// add `migrate` subcommand run instead of service, see migrate.go
+	app := service.Options().Cmd.App()
+	app.Commands = append(app.Commands, migrateCommand())
 
-	// create consul connection & agent
-	consulAddr := os.Getenv("CONSUL_ADDRESS")
-	if consulAddr == "" {
-		log.Fatal("please set CONSUL_ADDRESS in environment variable")
+	// load configuration with flags parsed in micro service, before connecting to anything
+	var conf config.Config
+	service.Init(micro.Action(func(c *cli.Context) (err error) {
+		conf, err = config.Load(c)
+		return
+	}))
+	if err := conf.Validate(); err != nil {
+		log.Fatalf("unable to load configuration, err: %v", err)
+	}
+	port := conf.Port
+	if port == 0 {
+		port = network.GetRandomPortNotInUsedWithRange(10101, 10200)
 	}
+	service.Init(micro.Address(fmt.Sprintf(":%d", port)))
+
+	if conf.Standalone {
+		runStandalone(service, rpcDrainer.Drain, conf)
+		return
+	}
+
+	// create consul connection & agent
 	consulCfg := api.DefaultConfig()
-	consulCfg.Address = consulAddr
+	consulCfg.Address = conf.Consul.Address
 	consulCli, err := api.NewClient(consulCfg)
 	if err != nil {
 		log.Fatalf("consul connect fail, err: %v", err)
 	}
-	consulAgent := consulagent.Default( // add in v.1.0.5
-		consulagent.Strategy(selector.RoundRobin),
+	nodeCachePath := conf.Consul.NodeCachePath
+	if nodeCachePath == "" {
+		nodeCachePath = filepath.Join(os.TempDir(), "club-consul-nodes.json")
+	}
+	// register HTTP check of health check server in consul, TTL check is used only in fallback mode
+	healthPort := conf.Health.Port
+	if healthPort == 0 {
+		healthPort = network.GetRandomPortNotInUsedWithRange(10201, 10300)
+	}
+	// round robin is default strategy, node stats are collected only in least outstanding & ewma latency strategy
+	var strategyOpt consulagent.FieldSetter
+	switch conf.Consul.Strategy {
+	case config.StrategyLeastOutstanding:
+		strategyOpt = consulagent.LeastOutstanding()
+	case config.StrategyEWMALatency:
+		strategyOpt = consulagent.EWMALatency(conf.Consul.EWMADecay, time.Duration(conf.Consul.EWMAErrorPenalty))
+	default:
+		strategyOpt = consulagent.Strategy(selector.RoundRobin)
+	}
+	agentOpts := []consulagent.FieldSetter{
+		strategyOpt,
 		consulagent.Client(consulCli),
+		consulagent.NodeCache(nodeCachePath),
 		consulagent.Services([]consul.ServiceName{topic.AuthServiceName, topic.ClubServiceName,
 			topic.OutingServiceName, topic.ScheduleServiceName, topic.AnnouncementServiceName}),
+	}
+	if conf.Health.Mode == config.HealthCheckModeHTTP {
+		agentOpts = append(agentOpts,
+			consulagent.HTTPCheck(healthPort, healthcheck.Path),
+			consulagent.CheckInterval(time.Second*10, time.Second*3),
+			consulagent.DeregisterCriticalAfter(time.Minute),
+		)
+	}
+	consulAgent := consulagent.Default(agentOpts...) // add in v.1.0.5
+
+	// route share of traffic or request with canary header to canary nodes of auth service
+	if conf.Auth.CanaryTag != "" {
+		consulAgent.SetRoutingRule(topic.AuthServiceName, consulagent.RoutingRule{
+			CanaryTag:   conf.Auth.CanaryTag,
+			Share:       conf.Auth.CanaryShare,
+			Header:      "X-Canary",
+			HeaderValue: "true",
+		})
+	}
+
+	// create runtime configuration watched in consul KV, default document is used if consul KV is unavailable
+	runtimeDoc := config.DefaultRuntimeDocument()
+	runtimeDoc.S3Bucket = conf.AWS.Bucket
+	runtimeConfig := config.Runtime(
+		config.ConsulClient(consulCli),
+		config.Key(conf.Consul.RuntimeKey),
+		config.Defaults(runtimeDoc),
+	)
+	if err := runtimeConfig.Load(); err != nil {
+		log.Printf("unable to load runtime configuration, use default document, err: %v", err)
+	}
+	featureFlags := config.Flags(
+		config.FlagsConsulClient(consulCli),
+		config.FlagsKey(conf.Consul.FlagsKey),
 	)
+	if err := featureFlags.Load(); err != nil {
+		log.Printf("unable to load feature flags, all flags are off, err: %v", err)
+	}
 
 	// create db access manager
-	dbc, _, err := db.ConnectWithConsul(consulCli, "db/club/local")
+	dbc, dbConf, err := db.ConnectWithConsul(consulCli, conf.Consul.DBKey, conf.DB.Password)
 	if err != nil {
 		log.Fatalf("db connect fail, err: %v", err)
 	}
-	if err := db.Migrate(dbc); err != nil {
+	if err := runMigrations(dbc, conf.DB.MigrationMode); err != nil {
 		log.Fatalf("db migration error, err: %v", err)
 	}
-	defaultAccessManage, err := db.NewAccessorManage(access.Default(dbc))
+	replicaDBs, err := db.ConnectReplicas(dbConf, conf.DB.Password)
+	if err != nil {
+		log.Fatalf("db replica connect fail, err: %v", err)
+	}
+	var replicaSetters []db.AccessorManageFieldSetter
+	for index, replicaDB := range replicaDBs {
+		replicaName := fmt.Sprintf("%s:%d", dbConf.Replicas[index].Host, dbConf.Replicas[index].Port)
+		replicaSetters = append(replicaSetters, db.ReadReplica(replicaName, access.Default(replicaDB), db.MysqlReplicaChecker(replicaDB)))
+	}
+	defaultAccessManage, err := db.NewAccessorManage(access.Default(dbc), replicaSetters...)
 	if err != nil {
 		log.Fatalf("db accessor create fail, err: %v", err)
 	}
 
 	// create jaeger connection
-	jaegerAddr := os.Getenv("JAEGER_ADDRESS")
-	if jaegerAddr == "" {
-		log.Fatal("please set JAEGER_ADDRESS in environment variable")
-	}
 	authSrvTracer, closer, err := jaegercfg.Configuration{
 		ServiceName: topic.ClubServiceName,
 		Tags:        []opentracing.Tag{{"sid", srvID}},
-		Reporter:    &jaegercfg.ReporterConfig{LogSpans: true, LocalAgentHostPort: jaegerAddr},
+		Reporter:    &jaegercfg.ReporterConfig{LogSpans: true, LocalAgentHostPort: conf.Jaeger.Address},
 		Sampler:     &jaegercfg.SamplerConfig{Type: jaeger.SamplerTypeConst, Param: 1},
 	}.NewTracer()
 	if err != nil {
 		log.Fatalf("error while creating new tracer for service, err: %v", err)
 	}
-	defer func() {
-		_ = closer.Close()
-	}()
 
 	// create AWS session
-	awsId := os.Getenv("SMS_AWS_ID")
-	if awsId == "" {
-		log.Fatal("please set SMS_AWS_ID in environment variable")
-	}
-	awsKey := os.Getenv("SMS_AWS_KEY")
-	if awsKey == "" {
-		log.Fatal("please set SMS_AWS_KEY in environment variable")
-	}
-	s3Region := os.Getenv("SMS_AWS_REGION")
-	if s3Region == "" {
-		log.Fatal("please set SMS_AWS_REGION in environment variable")
-	}
 	awsSession, err := session.NewSession(&aws.Config{
-		Region:      aws.String(s3Region),
-		Credentials: credentials.NewStaticCredentials(awsId, awsKey, ""),
+		Region:      aws.String(conf.AWS.Region),
+		Credentials: credentials.NewStaticCredentials(conf.AWS.ID, conf.AWS.Key, ""),
 	})
 	if err != nil {
 		log.Fatalf("error while creating new aws session, err: %v", err)
 	}
 
 	cliOpts := []client.Option{client.Transport(grpc.NewTransport())}
-	authStudentSrv := authproto.NewAuthStudentService(topic.AuthServiceName, grpccli.NewClient(cliOpts...))
+	authStudentSrv := authcli.Retry(
+		authproto.NewAuthStudentService(topic.AuthServiceName, grpccli.NewClient(cliOpts...)),
+		authcli.ConsulAgent(consulAgent),
+		authcli.Service(topic.AuthServiceName),
+	)
 	defaultHandler := handler.Default(
 		handler.AccessManager(defaultAccessManage),
 		handler.Tracer(authSrvTracer),
 		handler.ConsulAgent(consulAgent),
 		handler.AuthStudent(authStudentSrv),
-		handler.AWSSession(awsSession),
+		handler.LogoStorage(storage.S3(awsSession, runtimeConfig.S3Bucket)),
+		handler.RuntimeConfig(runtimeConfig),
+		handler.FeatureFlags(featureFlags),
 	)
 
-	// create subscriber & register listener (add in v.1.0.5)
-	consulChangeQueue := os.Getenv("CHANGE_CONSUL_SQS_CLUB")
-	if consulChangeQueue == "" {
-		log.Fatal("please set CHANGE_CONSUL_SQS_CLUB in environment variable")
-	}
-	subscriber.SetAwsSession(awsSession)
-	defaultSubscriber := subscriber.Default()
-	//defaultSubscriber.RegisterBeforeStart(
-	//	subscriber.SqsQueuePurger(consulChangeQueue),
-	//)
-	//defaultSubscriber.RegisterListeners(
-	//	subscriber.SqsMsgListener(consulChangeQueue, defaultHandler.ChangeConsulNodes, &sqs.ReceiveMessageInput{
-	//		MaxNumberOfMessages: aws.Int64(10),
-	//		WaitTimeSeconds:     aws.Int64(2),
-	//	}),
-	//)
+	// create subscriber & register handler (add in v.1.0.5)
+	consulChangeQueue := conf.SQS.ConsulChangeQueue
+	sqsBroker := subscriber.SqsBroker(awsSession, &sqs.ReceiveMessageInput{
+		MaxNumberOfMessages: aws.Int64(10),
+		WaitTimeSeconds:     aws.Int64(2),
+	},
+		subscriber.SqsWorkerCount(10),
+		subscriber.SqsVisibilityTimeout(time.Second*30),
+		subscriber.SqsDeadLetterQueue(conf.SQS.DeadLetterQueue, 5),
+	)
+	defaultSubscriber := subscriber.Default(subscriber.MessageBroker(sqsBroker))
+	eventRouter := subscriber.Router()
+	eventRouter.Handle(handler.EventConsulNodesChanged, defaultHandler.ChangeConsulNodes)
+	if featureFlags.IsEnabled(flagConsulChangeListener, srvID) {
+		defaultSubscriber.RegisterBeforeStart(
+			defaultSubscriber.QueuePurger(consulChangeQueue),
+		)
+		defaultSubscriber.RegisterHandler(consulChangeQueue, eventRouter.Route)
+	}
+
+	// create shutdown sequence run after deregistering from consul
+	sqlDB, err := dbc.DB()
+	if err != nil {
+		log.Fatalf("unable to get sql DB from gorm DB, err: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(runtimeConfig.DBMaxOpenConns())
+	sqlDB.SetMaxIdleConns(runtimeConfig.DBMaxIdleConns())
+	runtimeConfig.OnChange(func(doc config.RuntimeDocument) {
+		sqlDB.SetMaxOpenConns(doc.DBMaxOpenConns)
+		sqlDB.SetMaxIdleConns(doc.DBMaxIdleConns)
+	})
+	h := health.New()
+	healthServer := healthcheck.Server(healthPort, h)
+	shutdownSequence := graceful.Sequence(time.Duration(conf.ShutdownTimeout)).
+		Add("consul watch", consulAgent.StopWatching).
+		Add("runtime config watch", runtimeConfig.StopWatching).
+		Add("feature flags watch", featureFlags.StopWatching).
+		Add("subscriber", defaultSubscriber.StopListening).
+		Add("in-flight rpc", rpcDrainer.Drain).
+		Add("health check server", healthServer.Stop).
+		Add("db health checker", h.Stop).
+		Add("db replica monitor", defaultAccessManage.StopReplicaMonitor).
+		Add("db connection", sqlDB.Close).
+		Add("db replica connection", func() error {
+			for _, replicaDB := range replicaDBs {
+				if replicaSqlDB, err := replicaDB.DB(); err == nil {
+					_ = replicaSqlDB.Close()
+				}
+			}
+			return nil
+		}).
+		Add("jaeger tracer", closer.Close)
 
 	service.Init(
 		micro.BeforeStart(consulAgent.ChangeAllServiceNodes),
 		micro.AfterStart(consulAgent.ChangeAllServiceNodes),
+		micro.BeforeStart(defaultAccessManage.StartReplicaMonitor),
+		micro.AfterStart(consulAgent.StartWatching),
+		micro.AfterStart(runtimeConfig.StartWatching),
+		micro.AfterStart(featureFlags.StartWatching),
 		micro.AfterStart(defaultSubscriber.StartListening),
+		micro.AfterStart(healthServer.Start),
 		micro.AfterStart(consulAgent.ServiceNodeRegistry(service.Server())),
 		micro.BeforeStop(consulAgent.ServiceNodeDeregistry(service.Server())),
+		micro.BeforeStop(shutdownSequence.Run),
 	)
 
 	_ = clubproto.RegisterClubAdminHandler(service.Server(), defaultHandler)
@@ -154,11 +280,6 @@ func main() {
 	_ = clubproto.RegisterClubEventHandler(service.Server(), defaultHandler)
 
 	// DB Health checker 실행
-	sqlDB, err := dbc.DB()
-	if err != nil {
-		log.Fatalf("unable to get sql DB from gorm DB, err: %v", err)
-	}
-	h := health.New()
 	dbChecker, err := checkers.NewSQL(&checkers.SQLConfig{
 		Pinger: sqlDB,
 	})
@@ -166,12 +287,24 @@ func main() {
 		log.Fatalf("unable to create sql health checker, err: %v", err)
 	}
 	dbHealthCfg := &health.Config{
-		Name:       "DB-Checker",
-		Checker:    dbChecker,
-		Interval:   time.Second * 5,
-		OnComplete: closure.TTLCheckHandlerAboutDB(service.Server(), consulCli),
+		Name:     "DB-Checker",
+		Checker:  dbChecker,
+		Interval: time.Second * 5,
+	}
+	if conf.Health.Mode == config.HealthCheckModeTTL {
+		dbHealthCfg.OnComplete = closure.TTLCheckHandlerAboutDB(service.Server(), consulCli)
+	}
+	storageHealthCfg := &health.Config{
+		Name:     "Storage-Checker",
+		Checker:  healthcheck.S3Bucket(awsSession, runtimeConfig.S3Bucket),
+		Interval: time.Second * 30,
+	}
+	authHealthCfg := &health.Config{
+		Name:     "Auth-Checker",
+		Checker:  healthcheck.ServiceReachable(consulAgent, topic.AuthServiceName, time.Second*3),
+		Interval: time.Second * 10,
 	}
-	if err = h.AddChecks([]*health.Config{dbHealthCfg}); err != nil {
+	if err = h.AddChecks([]*health.Config{dbHealthCfg, storageHealthCfg, authHealthCfg}); err != nil {
 		log.Fatalf("unable to register health checks, err: %v", err)
 	}
 	if err = h.Start(); err != nil {
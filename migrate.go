@@ -0,0 +1,123 @@
+// migrate.go is file to declare `migrate` subcommand applying, rolling back & showing status of DB migrations
+// run with `./club-service migrate up`, `./club-service migrate down --steps 1` or `./club-service migrate status`
+
+package main
+
+import (
+	"club/config"
+	"club/db"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/consul/api"
+	"github.com/micro/cli/v2"
+	"gorm.io/gorm"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// returns subcommand added to command of micro service, process exits after subcommand is run
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "apply, roll back or show status of DB migrations",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "apply all pending migrations",
+				Action: migrateAction(func(c *cli.Context, dbc *gorm.DB) error {
+					applied, err := db.MigrateUp(dbc)
+					log.Printf("%d migrations applied", len(applied))
+					return err
+				}),
+			}, {
+				Name:  "down",
+				Usage: "roll back latest applied migrations",
+				Flags: []cli.Flag{&cli.IntFlag{Name: "steps", Usage: "number of migrations to roll back", Value: 1}},
+				Action: migrateAction(func(c *cli.Context, dbc *gorm.DB) error {
+					rolledBack, err := db.MigrateDown(dbc, c.Int("steps"))
+					log.Printf("%d migrations rolled back", len(rolledBack))
+					return err
+				}),
+			}, {
+				Name:  "status",
+				Usage: "show applied & pending migrations",
+				Action: migrateAction(func(c *cli.Context, dbc *gorm.DB) error {
+					states, err := db.MigrationStatus(dbc)
+					if err != nil {
+						return err
+					}
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+					for _, state := range states {
+						status, appliedAt := "pending", ""
+						if state.Applied {
+							status, appliedAt = "applied", state.AppliedAt.Format("2006-01-02 15:04:05")
+						}
+						if state.Unknown {
+							status = "applied (unknown)"
+						}
+						_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", state.Version, state.Name, status, appliedAt)
+					}
+					return w.Flush()
+				}),
+			},
+		},
+	}
+}
+
+// wrap migration function with loading configuration & connecting to DB, and exit process after it returns
+func migrateAction(fn func(*cli.Context, *gorm.DB) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		conf, err := config.Load(c)
+		if err != nil {
+			log.Fatalf("unable to load configuration, err: %v", err)
+		}
+		dbc, err := connectDB(conf)
+		if err != nil {
+			log.Fatalf("db connect fail, err: %v", err)
+		}
+		if err = fn(c, dbc); err != nil {
+			log.Fatalf("db migration error, err: %v", err)
+		}
+		os.Exit(0)
+		return nil
+	}
+}
+
+// connect to DB with DSN in standalone mode, or with connection config in consul KV
+func connectDB(conf config.Config) (dbc *gorm.DB, err error) {
+	if conf.Standalone {
+		return db.Connect(conf.DB.Dialect, conf.DB.DSN)
+	}
+
+	consulCfg := api.DefaultConfig()
+	consulCfg.Address = conf.Consul.Address
+	consulCli, err := api.NewClient(consulCfg)
+	if err != nil {
+		return
+	}
+	dbc, _, err = db.ConnectWithConsul(consulCli, conf.Consul.DBKey, conf.DB.Password)
+	return
+}
+
+// apply pending migrations in auto mode, or return error if there are pending migrations in check mode
+func runMigrations(dbc *gorm.DB, mode string) error {
+	if mode == config.MigrationModeAuto {
+		return db.Migrate(dbc)
+	}
+
+	pending, err := db.PendingMigrations(dbc)
+	if err != nil {
+		return err
+	}
+	if len(pending) != 0 {
+		var versions []string
+		for _, migration := range pending {
+			versions = append(versions, fmt.Sprintf("%d (%s)", migration.Version, migration.Name))
+		}
+		return errors.New(fmt.Sprintf("there are pending migrations, run `migrate up` before starting service, pending: %s", strings.Join(versions, ", ")))
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 const (
 	emptyString = ""
 	emptyInt = 0
+	initialVersion = 1
 
 	validClubUUID = "club-111111111111"
 	validRecruitmentUUID = "recruitment-111111111111"
@@ -22,13 +23,18 @@ const (
 	validRecruitConcept = "디자인에 좋은 감각이 있는 새로운 1학년 부원을 모집합니다!"
 )
 
+// 중복 값 존재 여부 사전 확인 함수 -> 실제 중복 방지는 soft delete를 고려한 unique index가 담당 (migration 3)
+// 동시 요청에선 통과할 수 있으므로, index에 막히기 전 친절한 Duplicate Entry 에러를 반환하는 용도로만 사용해야 함!!
+func alreadyExist(tx *gorm.DB, model interface{}, query string, args ...interface{}) bool {
+	return tx.Select("id").Where(query, args...).Limit(1).Find(model).RowsAffected != 0
+}
+
 func (c *Club) BeforeCreate(tx *gorm.DB) (err error) {
 	if err = validate.DBValidator.Struct(c); err != nil {
 		return
 	}
 
-	selectResult := tx.Where("leader_uuid = ?", c.LeaderUUID).Find(&Club{})
-	if selectResult.RowsAffected != 0 {
+	if alreadyExist(tx, &Club{}, "leader_uuid = ?", c.LeaderUUID) {
 		err = mysqlerr.DuplicateEntry(ClubInstance.LeaderUUID.KeyName(), string(c.LeaderUUID))
 	}
 	return
@@ -39,12 +45,16 @@ func (ci *ClubInform) BeforeCreate(tx *gorm.DB) (err error) {
 		return
 	}
 
-	if tx.Where("name = ?", ci.Name).Find(&ClubInform{}).RowsAffected != 0 {
+	if ci.Version == emptyInt {
+		ci.Version = initialVersion
+	}
+
+	if alreadyExist(tx, &ClubInform{}, "name = ?", ci.Name) {
 		err = mysqlerr.DuplicateEntry(ClubInformInstance.Name.KeyName(), string(ci.Name))
 		return
 	}
 
-	if tx.Where("location = ?", ci.Location).Find(&ClubInform{}).RowsAffected != 0 {
+	if alreadyExist(tx, &ClubInform{}, "location = ?", ci.Location) {
 		err = mysqlerr.DuplicateEntry(ClubInformInstance.Location.KeyName(), string(ci.Location))
 		return
 	}
@@ -57,15 +67,21 @@ func (cm *ClubMember) BeforeCreate(tx *gorm.DB) (err error) {
 		return
 	}
 
-	selectResult := tx.Where("club_uuid = ? AND student_uuid = ?", cm.ClubUUID, cm.StudentUUID).Find(&ClubMember{})
-	if selectResult.RowsAffected != 0 {
+	if alreadyExist(tx, &ClubMember{}, "club_uuid = ? AND student_uuid = ?", cm.ClubUUID, cm.StudentUUID) {
 		err = mysqlerr.DuplicateEntry(ClubMemberInstance.StudentUUID.KeyName(), fmt.Sprintf("%s.%s", cm.ClubUUID, cm.StudentUUID))
 	}
 	return
 }
 
-func (cr *ClubRecruitment) BeforeCreate(tx *gorm.DB) error {
-	return validate.DBValidator.Struct(cr)
+func (cr *ClubRecruitment) BeforeCreate(tx *gorm.DB) (err error) {
+	if err = validate.DBValidator.Struct(cr); err != nil {
+		return
+	}
+
+	if cr.Version == emptyInt {
+		cr.Version = initialVersion
+	}
+	return
 }
 
 func (rm *RecruitMember) BeforeCreate(tx *gorm.DB) error {
@@ -82,7 +98,7 @@ func (c *Club) BeforeUpdate(tx *gorm.DB) (err error) {
 		return
 	}
 
-	if c.LeaderUUID != "" && tx.Where("leader_uuid = ?", c.LeaderUUID).Find(&Club{}).RowsAffected != 0 {
+	if c.LeaderUUID != "" && alreadyExist(tx, &Club{}, "leader_uuid = ?", c.LeaderUUID) {
 		err = mysqlerr.DuplicateEntry(ClubInstance.LeaderUUID.KeyName(), string(c.LeaderUUID))
 	}
 	return
@@ -102,12 +118,12 @@ func (ci *ClubInform) BeforeUpdate(tx *gorm.DB) (err error) {
 		return
 	}
 
-	if ci.Name != "" && tx.Where("name = ?", ci.Name).Find(&ClubInform{}).RowsAffected != 0 {
+	if ci.Name != "" && alreadyExist(tx, &ClubInform{}, "name = ?", ci.Name) {
 		err = mysqlerr.DuplicateEntry(ClubInformInstance.Name.KeyName(), string(ci.Name))
 		return
 	}
 
-	if ci.Location != "" && tx.Where("location = ?", ci.Location).Find(&ClubInform{}).RowsAffected != 0 {
+	if ci.Location != "" && alreadyExist(tx, &ClubInform{}, "location = ?", ci.Location) {
 		err = mysqlerr.DuplicateEntry(ClubInformInstance.Location.KeyName(), string(ci.Location))
 		return
 	}
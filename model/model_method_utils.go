@@ -23,6 +23,11 @@ func exceptGormModel(model interface{}) (gormModelExceptTable interface{}) {
 	reflect.ValueOf(gormModelExceptTable).Elem().FieldByName("CreatedAt").Set(reflect.ValueOf(time.Time{}))
 	reflect.ValueOf(gormModelExceptTable).Elem().FieldByName("UpdatedAt").Set(reflect.ValueOf(time.Time{}))
 	reflect.ValueOf(gormModelExceptTable).Elem().FieldByName("DeletedAt").Set(reflect.ValueOf(gorm.DeletedAt{}))
+
+	// Version 필드 또한 gorm.Model 필드처럼 DB에서 관리되는 값이므로 존재하는 경우 초기화
+	if versionField := reflect.ValueOf(gormModelExceptTable).Elem().FieldByName("Version"); versionField.IsValid() {
+		versionField.Set(reflect.Zero(versionField.Type()))
+	}
 	return
 }
 
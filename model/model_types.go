@@ -3,7 +3,9 @@ package model
 import (
 	"club/tool/random"
 	"database/sql/driver"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -21,91 +23,91 @@ func init() {
 type uuid string
 func UUID(s string) uuid { return uuid(s) }
 func (u uuid) Value() (driver.Value, error) { return string(u), nil }
-func (u *uuid) Scan(src interface{}) (err error) { *u = uuid(src.([]uint8)); return }
+func (u *uuid) Scan(src interface{}) error { return scanString(src, (*string)(u)) }
 func (u uuid) KeyName() string { return "uuid" }
 
 // LeaderUUID 필드에서 사용할 사용자 정의 타입
 type leaderUUID string
 func LeaderUUID(s string) leaderUUID { return leaderUUID(s) }
 func (lu leaderUUID) Value() (driver.Value, error) { return string(lu), nil }
-func (lu *leaderUUID) Scan(src interface{}) (err error) { *lu = leaderUUID(src.([]uint8)); return }
+func (lu *leaderUUID) Scan(src interface{}) error { return scanString(src, (*string)(lu)) }
 func (lu leaderUUID) KeyName() string { return "leader_uuid" }
 
 // ClubUUID 필드에서 사용할 사용자 정의 타입
 type clubUUID string
 func ClubUUID(s string) clubUUID { return clubUUID(s) }
 func (cu clubUUID) Value() (driver.Value, error) { return string(cu), nil }
-func (cu *clubUUID) Scan(src interface{}) (err error) { *cu = clubUUID(src.([]uint8)); return }
+func (cu *clubUUID) Scan(src interface{}) error { return scanString(src, (*string)(cu)) }
 func (cu clubUUID) KeyName() string { return "club_uuid" }
 
 // Name 필드에서 사용할 사용자 정의 타입
 type name string
 func Name(s string) name { return name(s) }
 func (n name) Value() (driver.Value, error) { return string(n), nil }
-func (n *name) Scan(src interface{}) (err error) { *n = name(src.([]uint8)); return }
+func (n *name) Scan(src interface{}) error { return scanString(src, (*string)(n)) }
 func (n name) KeyName() string { return "name" }
 
 // ClubConcept 필드에서 사용할 사용자 정의 타입
 type clubConcept string
 func ClubConcept(s string) clubConcept { return clubConcept(s) }
 func (cc clubConcept) Value() (driver.Value, error) { return string(cc), nil }
-func (cc *clubConcept) Scan(src interface{}) (err error) { *cc = clubConcept(src.([]uint8)); return }
+func (cc *clubConcept) Scan(src interface{}) error { return scanString(src, (*string)(cc)) }
 func (cc clubConcept) KeyName() string { return "club_concept" }
 
 // Introduction 필드에서 사용할 사용자 정의 타입
 type introduction string
 func Introduction(s string) introduction { return introduction(s) }
 func (i introduction) Value() (driver.Value, error) { return string(i), nil }
-func (i *introduction) Scan(src interface{}) (err error) { *i = introduction(src.([]uint8)); return }
+func (i *introduction) Scan(src interface{}) error { return scanString(src, (*string)(i)) }
 func (i introduction) KeyName() string { return "introduction" }
 
 // Introduction 필드에서 사용할 사용자 정의 타입
 type field string
 func Field(s string) field { return field(s) }
 func (f field) Value() (driver.Value, error) { return string(f), nil }
-func (f *field) Scan(src interface{}) (err error) { *f = field(src.([]uint8)); return }
+func (f *field) Scan(src interface{}) error { return scanString(src, (*string)(f)) }
 func (f field) KeyName() string { return "field" }
 
 // Introduction 필드에서 사용할 사용자 정의 타입
 type location string
 func Location(s string) location { return location(s) }
 func (l location) Value() (driver.Value, error) { return string(l), nil }
-func (l *location) Scan(src interface{}) (err error) { *l = location(src.([]uint8)); return }
+func (l *location) Scan(src interface{}) error { return scanString(src, (*string)(l)) }
 func (l location) KeyName() string { return "location" }
 
 // Floor 필드에서 사용할 사용자 정의 타입
 type floor string
 func Floor(s string) floor { return floor(s) }
 func (f floor) Value() (driver.Value, error) { return string(f), nil }
-func (f *floor) Scan(src interface{}) (err error) { *f = floor(src.([]uint8)); return }
+func (f *floor) Scan(src interface{}) error { return scanString(src, (*string)(f)) }
 func (f floor) KeyName() string { return "floor" }
 
 // Link 필드에서 사용할 사용자 정의 타입
 type link string
 func Link(s string) link { return link(s) }
 func (l link) Value() (driver.Value, error) { return string(l), nil }
-func (l *link) Scan(src interface{}) (err error) { *l = link(src.([]uint8)); return }
+func (l *link) Scan(src interface{}) error { return scanString(src, (*string)(l)) }
 func (l link) KeyName() string { return "link" }
 
 // LogoURI 필드에서 사용할 사용자 정의 타입
 type logoURI string
 func LogoURI(s string) logoURI { return logoURI(s) }
 func (lu logoURI) Value() (driver.Value, error) { return string(lu), nil }
-func (lu *logoURI) Scan(src interface{}) (err error) { *lu = logoURI(src.([]uint8)); return }
+func (lu *logoURI) Scan(src interface{}) error { return scanString(src, (*string)(lu)) }
 func (lu logoURI) KeyName() string { return "logo_uri" }
 
 // StudentUUID 필드에서 사용할 사용자 정의 타입
 type studentUUID string
 func StudentUUID(s string) studentUUID { return studentUUID(s) }
 func (su studentUUID) Value() (driver.Value, error) { return string(su), nil }
-func (su *studentUUID) Scan(src interface{}) (err error) { *su = studentUUID(src.([]uint8)); return }
+func (su *studentUUID) Scan(src interface{}) error { return scanString(src, (*string)(su)) }
 func (su studentUUID) KeyName() string { return "student_uuid" }
 
 // RecruitConcept 필드에서 사용할 사용자 정의 타입
 type recruitConcept string
 func RecruitConcept(s string) recruitConcept { return recruitConcept(s) }
 func (rc recruitConcept) Value() (driver.Value, error) { return string(rc), nil }
-func (rc *recruitConcept) Scan(src interface{}) (err error) { *rc = recruitConcept(src.([]uint8)); return }
+func (rc *recruitConcept) Scan(src interface{}) error { return scanString(src, (*string)(rc)) }
 func (rc recruitConcept) KeyName() string { return "recruit_concept" }
 
 // StartPeriod 필드에서 사용할 사용자 정의 타입
@@ -120,7 +122,7 @@ func (sp startPeriod) Value() (value driver.Value, err error) {
 	}
 	return
 }
-func (sp *startPeriod) Scan(src interface{}) (err error) { *sp = startPeriod(src.(time.Time)); return }
+func (sp *startPeriod) Scan(src interface{}) error { return scanTime(src, (*time.Time)(sp)) }
 func (sp startPeriod) KeyName() string { return "start_period" }
 func (sp startPeriod) NullReplaceValue() time.Time { return nullReplaceValueForStartPeriod  }
 
@@ -136,7 +138,7 @@ func (ep endPeriod) Value() (value driver.Value, err error) {
 	}
 	return
 }
-func (ep *endPeriod) Scan(src interface{}) (err error) { *ep = endPeriod(src.(time.Time)); return }
+func (ep *endPeriod) Scan(src interface{}) error { return scanTime(src, (*time.Time)(ep)) }
 func (ep endPeriod) KeyName() string { return "end_period" }
 func (ep endPeriod) NullReplaceValue() time.Time { return nullReplaceValueForEndPeriod  }
 
@@ -144,19 +146,100 @@ func (ep endPeriod) NullReplaceValue() time.Time { return nullReplaceValueForEnd
 type recruitmentUUID string
 func RecruitmentUUID(s string) recruitmentUUID { return recruitmentUUID(s) }
 func (ru recruitmentUUID) Value() (driver.Value, error) { return string(ru), nil }
-func (ru *recruitmentUUID) Scan(src interface{}) (err error) { *ru = recruitmentUUID(src.([]uint8)); return }
+func (ru *recruitmentUUID) Scan(src interface{}) error { return scanString(src, (*string)(ru)) }
 func (ru recruitmentUUID) KeyName() string { return "recruitment_uuid" }
 
 // Grade 필드에서 사용할 사용자 정의 타입
 type grade string
 func Grade(s string) grade { return grade(s) }
 func (g grade) Value() (value driver.Value, err error) { return string(g), nil }
-func (g *grade) Scan(src interface{}) (_ error) { *g = grade(src.([]uint8)); return }
+func (g *grade) Scan(src interface{}) error { return scanString(src, (*string)(g)) }
 func (g grade) KeyName() string { return "grade" }
 
 // StudentNumber 필드에서 사용할 사용자 정의 타입
 type number string
 func Number(s string) number { return number(s) }
 func (n number) Value() (driver.Value, error) { return string(n), nil }
-func (n *number) Scan(src interface{}) (err error) { *n = number(src.([]uint8)); return }
+func (n *number) Scan(src interface{}) error { return scanString(src, (*string)(n)) }
 func (n number) KeyName() string { return "number" }
+
+// Version 필드에서 사용할 사용자 정의 타입 (낙관적 동시성 제어를 위해 수정 시 마다 1씩 증가)
+type version uint
+func Version(u uint) version { return version(u) }
+func (v version) Value() (driver.Value, error) { return int64(v), nil }
+func (v *version) Scan(src interface{}) error { return scanUint(src, (*uint)(v)) }
+func (v version) KeyName() string { return "version" }
+
+// 시간 문자열을 time.Time 으로 변환할 때 시도할 형식 목록 (SQLite 등 드라이버가 문자열로 반환하는 경우)
+var timeFormatsForScan = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+// 문자열 기반 사용자 정의 타입의 Scan 메서드에서 사용하는 함수 ([]uint8, string, NULL 값 처리)
+func scanString(src interface{}, dest *string) error {
+	switch v := src.(type) {
+	case []uint8:
+		*dest = string(v)
+	case string:
+		*dest = v
+	case nil:
+		*dest = emptyString
+	default:
+		return errors.New(fmt.Sprintf("unable to scan %T value into string type", src))
+	}
+	return nil
+}
+
+// 시간 기반 사용자 정의 타입의 Scan 메서드에서 사용하는 함수 (time.Time, []uint8, string, NULL 값 처리)
+func scanTime(src interface{}, dest *time.Time) error {
+	var str string
+	switch v := src.(type) {
+	case time.Time:
+		*dest = v
+		return nil
+	case nil:
+		*dest = time.Time{}
+		return nil
+	case []uint8:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return errors.New(fmt.Sprintf("unable to scan %T value into time type", src))
+	}
+
+	for _, format := range timeFormatsForScan {
+		if parsed, err := time.ParseInLocation(format, str, time.Local); err == nil {
+			*dest = parsed
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("unable to parse %s into time type", str))
+}
+
+// 정수 기반 사용자 정의 타입의 Scan 메서드에서 사용하는 함수 (int64, []uint8, string, NULL 값 처리)
+func scanUint(src interface{}, dest *uint) error {
+	switch v := src.(type) {
+	case int64:
+		*dest = uint(v)
+	case []uint8:
+		return scanUint(string(v), dest)
+	case string:
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return errors.New(fmt.Sprintf("unable to parse %s into uint type", v))
+		}
+		*dest = uint(parsed)
+	case nil:
+		*dest = emptyInt
+	default:
+		return errors.New(fmt.Sprintf("unable to scan %T value into uint type", src))
+	}
+	return nil
+}
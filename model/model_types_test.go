@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_uuid_Scan(t *testing.T) {
+	tests := []struct {
+		Src           interface{}
+		ExpectedUUID  uuid
+		ExpectedError bool
+	}{
+		{ // []uint8 returned from mysql driver
+			Src:          []uint8("club-123412341234"),
+			ExpectedUUID: "club-123412341234",
+		}, { // string returned from sqlite driver
+			Src:          "club-123412341234",
+			ExpectedUUID: "club-123412341234",
+		}, { // NULL
+			Src:          nil,
+			ExpectedUUID: "",
+		}, { // unsupported type
+			Src:           123,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		var u uuid
+		err := u.Scan(testCase.Src)
+		assert.Equalf(t, testCase.ExpectedError, err != nil, "error assertion error (test case: %v)", testCase)
+		assert.Equalf(t, testCase.ExpectedUUID, u, "uuid assertion error (test case: %v)", testCase)
+	}
+}
+
+func Test_startPeriod_Scan(t *testing.T) {
+	date := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		Src           interface{}
+		ExpectedTime  time.Time
+		ExpectedError bool
+	}{
+		{ // time.Time returned from mysql driver with parseTime
+			Src:          date,
+			ExpectedTime: date,
+		}, { // date string
+			Src:          "2020-03-01",
+			ExpectedTime: date,
+		}, { // datetime []uint8
+			Src:          []uint8("2020-03-01 00:00:00"),
+			ExpectedTime: date,
+		}, { // NULL
+			Src:          nil,
+			ExpectedTime: time.Time{},
+		}, { // invalid time string
+			Src:           "not time",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		var sp startPeriod
+		err := sp.Scan(testCase.Src)
+		assert.Equalf(t, testCase.ExpectedError, err != nil, "error assertion error (test case: %v)", testCase)
+		assert.Truef(t, testCase.ExpectedTime.Equal(time.Time(sp)), "time assertion error (test case: %v)", testCase)
+	}
+}
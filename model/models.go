@@ -21,6 +21,7 @@ type ClubInform struct {
 	Floor        floor        `gorm:"Type:char(1);NOT NULL" validate:"strRange=1~5"`
 	Link         link         `gorm:"Type:varchar(100)" validate:"max=100"`
 	LogoURI      logoURI      `gorm:"Type:varchar(100);NOT NULL" validate:"min=1,max=100"`
+	Version      version      `gorm:"NOT NULL;DEFAULT:1"`
 	Club         *Club        `gorm:"foreignKey:ClubUUID;references:UUID"`
 }
 
@@ -38,6 +39,7 @@ type ClubRecruitment struct {
 	RecruitConcept recruitConcept `gorm:"Type:varchar(40);NOT NULL" validate:"min=1,max=40"`
 	StartPeriod    startPeriod    `gorm:"Type:datetime"`
 	EndPeriod      endPeriod      `gorm:"Type:datetime"`
+	Version        version        `gorm:"NOT NULL;DEFAULT:1"`
 	Club           *Club          `gorm:"foreignKey:ClubUUID;references:UUID"`
 }
 
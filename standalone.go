@@ -0,0 +1,81 @@
+// standalone.go is file to declare function running service without consul, jaeger & AWS, used for local development
+// run with `make dev DSN="root:password@tcp(localhost:3306)/club?charset=utf8mb4&parseTime=True&loc=Local"`
+// or `make dev DIALECT=sqlite DSN=club.db` without MySQL
+
+package main
+
+import (
+	authcli "club/client/auth"
+	"club/config"
+	consulagent "club/consul/agent"
+	"club/db"
+	"club/db/access"
+	"club/handler"
+	authproto "club/proto/golang/auth"
+	clubproto "club/proto/golang/club"
+	"club/storage"
+	"club/tool/graceful"
+	topic "club/utils/topic/golang"
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/client"
+	grpccli "github.com/micro/go-micro/v2/client/grpc"
+	"github.com/micro/go-micro/v2/transport/grpc"
+	"github.com/opentracing/opentracing-go"
+	"log"
+	"time"
+)
+
+// run service with DB connected with DSN, static auth nodes & logo storage in local directory set in conf
+func runStandalone(service micro.Service, drainRPC func() error, conf config.Config) {
+	staticAgent := consulagent.Static(
+		consulagent.StaticNodes(topic.AuthServiceName, conf.Auth.Nodes...),
+	)
+
+	dbc, err := db.Connect(conf.DB.Dialect, conf.DB.DSN)
+	if err != nil {
+		log.Fatalf("db connect fail, err: %v", err)
+	}
+	if err := runMigrations(dbc, conf.DB.MigrationMode); err != nil {
+		log.Fatalf("db migration error, err: %v", err)
+	}
+	defaultAccessManage, err := db.NewAccessorManage(access.Default(dbc))
+	if err != nil {
+		log.Fatalf("db accessor create fail, err: %v", err)
+	}
+	sqlDB, err := dbc.DB()
+	if err != nil {
+		log.Fatalf("unable to get sql DB from gorm DB, err: %v", err)
+	}
+
+	cliOpts := []client.Option{client.Transport(grpc.NewTransport())}
+	authStudentSrv := authcli.Retry(
+		authproto.NewAuthStudentService(topic.AuthServiceName, grpccli.NewClient(cliOpts...)),
+		authcli.ConsulAgent(staticAgent),
+		authcli.Service(topic.AuthServiceName),
+	)
+	defaultHandler := handler.Default(
+		handler.AccessManager(defaultAccessManage),
+		handler.Tracer(opentracing.NoopTracer{}),
+		handler.ConsulAgent(staticAgent),
+		handler.AuthStudent(authStudentSrv),
+		handler.LogoStorage(storage.FileSystem(conf.LogoDir)),
+	)
+
+	shutdownSequence := graceful.Sequence(time.Duration(conf.ShutdownTimeout)).
+		Add("in-flight rpc", drainRPC).
+		Add("db connection", sqlDB.Close)
+	service.Init(
+		micro.BeforeStop(shutdownSequence.Run),
+	)
+
+	_ = clubproto.RegisterClubAdminHandler(service.Server(), defaultHandler)
+	_ = clubproto.RegisterClubStudentHandler(service.Server(), defaultHandler)
+	_ = clubproto.RegisterClubLeaderHandler(service.Server(), defaultHandler)
+	_ = clubproto.RegisterClubEventHandler(service.Server(), defaultHandler)
+
+	log.Printf("run service in standalone mode!! (address: %s, auth nodes: %v, logo dir: %s)",
+		service.Server().Options().Address, conf.Auth.Nodes, conf.LogoDir)
+	if err := service.Run(); err != nil {
+		log.Fatalf("error occurs while running service, err: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+// filesystem.go is file to declare storage writing object in local directory, used in standalone mode
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type fileSystem struct {
+	root string
+}
+
+// object is written in file of path joined root directory with key
+func FileSystem(root string) *fileSystem {
+	return &fileSystem{root: root}
+}
+
+func (f *fileSystem) Put(key string, body []byte) error {
+	path := filepath.Join(f.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(f.root)+string(filepath.Separator)) {
+		return errors.New(fmt.Sprintf("key must be in root directory, key: %s", key))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.New(fmt.Sprintf("unable to create directory, err: %v", err))
+	}
+	if err := ioutil.WriteFile(path, body, 0644); err != nil {
+		return errors.New(fmt.Sprintf("unable to write file, err: %v", err))
+	}
+	return nil
+}
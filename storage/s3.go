@@ -0,0 +1,30 @@
+// s3.go is file to declare storage putting object in AWS S3 bucket with public-read ACL
+
+package storage
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+type _s3 struct {
+	awsSession *session.Session
+	bucket     func() string
+}
+
+// bucket is function returning bucket name, so that bucket can be changed in runtime configuration
+func S3(awsSession *session.Session, bucket func() string) *_s3 {
+	return &_s3{awsSession: awsSession, bucket: bucket}
+}
+
+func (s *_s3) Put(key string, body []byte) (err error) {
+	_, err = s3.New(s.awsSession).PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket()),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+		ACL:    aws.String("public-read"),
+	})
+	return
+}
@@ -0,0 +1,9 @@
+// storage package is used for storing object like club logo in S3 or local filesystem
+// storage.go is file to declare Storage interface implemented in each file of this package
+
+package storage
+
+type Storage interface {
+	// method to store body with key (ex. logos/club-123412341234), existing object with key is overwritten
+	Put(key string, body []byte) error
+}
@@ -1,79 +1,344 @@
 // Add package in v.1.0.5
-// listener is function that return closure used in subscribe
-// aws_sqs.go is file that declare various closure about aws sqs like listening message, purging queue, etc ...
+// aws_sqs.go is file that declare Broker implementation about aws sqs like listening message, purging queue, etc ...
 
 package subscriber
 
 import (
+	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	log "github.com/micro/go-micro/v2/logger"
-	systemlog "log"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
 )
 
-// function signature type for sqs message handler
-type sqsMsgHandler func(*sqs.Message) error
+const (
+	approximateReceiveCountAttr = "ApproximateReceiveCount"
 
-// function that returns closure listening aws sqs message & handling with function receive from parameter
-func SqsMsgListener(queue string, handler sqsMsgHandler, rcvInput *sqs.ReceiveMessageInput) func() {
-	sqsSrv := sqs.New(awsSession)
-	urlResult, err := sqsSrv.GetQueueUrl(&sqs.GetQueueUrlInput{
+	defaultSqsWorkerCount       = 10
+	defaultSqsVisibilityTimeout = time.Second * 30
+	defaultSqsMinBackoff        = time.Second
+	defaultSqsMaxBackoff        = time.Minute
+)
+
+type sqsBroker struct {
+	sqsSrv            sqsiface.SQSAPI
+	rcvInput          sqs.ReceiveMessageInput
+	workerCount       int
+	visibilityTimeout time.Duration
+	maxReceiveCount   int
+	deadLetterQueue   string
+	minBackoff        time.Duration
+	maxBackoff        time.Duration
+}
+
+type SqsFieldSetter func(*sqsBroker)
+
+// function that returns Broker implementation using aws sqs, rcvInput is used as template of ReceiveMessageInput
+func SqsBroker(s *session.Session, rcvInput *sqs.ReceiveMessageInput, setters ...SqsFieldSetter) *sqsBroker {
+	b := &sqsBroker{
+		sqsSrv:            sqs.New(s),
+		workerCount:       defaultSqsWorkerCount,
+		visibilityTimeout: defaultSqsVisibilityTimeout,
+		minBackoff:        defaultSqsMinBackoff,
+		maxBackoff:        defaultSqsMaxBackoff,
+	}
+	if rcvInput != nil {
+		b.rcvInput = *rcvInput
+	}
+	for _, setter := range setters {
+		setter(b)
+	}
+	return b
+}
+
+// set max number of message handled concurrently in one subscription
+func SqsWorkerCount(n int) SqsFieldSetter {
+	return func(b *sqsBroker) {
+		b.workerCount = n
+	}
+}
+
+// set visibility timeout of received message, it is extended periodically while handler is running
+func SqsVisibilityTimeout(d time.Duration) SqsFieldSetter {
+	return func(b *sqsBroker) {
+		b.visibilityTimeout = d
+	}
+}
+
+// set max receive count of message, message received more than count is moved to dead letter queue
+func SqsDeadLetterQueue(queue string, maxReceiveCount int) SqsFieldSetter {
+	return func(b *sqsBroker) {
+		b.deadLetterQueue = queue
+		b.maxReceiveCount = maxReceiveCount
+	}
+}
+
+// set min & max backoff duration used when receiving message from aws sqs fails
+func SqsBackoff(min, max time.Duration) SqsFieldSetter {
+	return func(b *sqsBroker) {
+		b.minBackoff = min
+		b.maxBackoff = max
+	}
+}
+
+func (b *sqsBroker) Subscribe(queue string, handler MsgHandler) (Subscription, error) {
+	queueUrl, err := b.getQueueUrl(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadLetterQueueUrl *string
+	if b.deadLetterQueue != "" {
+		if deadLetterQueueUrl, err = b.getQueueUrl(b.deadLetterQueue); err != nil {
+			return nil, err
+		}
+	}
+
+	rcvInput := b.rcvInput
+	rcvInput.QueueUrl = queueUrl
+	rcvInput.VisibilityTimeout = aws.Int64(int64(b.visibilityTimeout / time.Second))
+	// copy attribute names of template, so that subscriptions don't share backing array of slice
+	rcvInput.AttributeNames = append(append([]*string{}, b.rcvInput.AttributeNames...), aws.String(approximateReceiveCountAttr))
+	if len(rcvInput.MessageAttributeNames) == 0 {
+		rcvInput.MessageAttributeNames = []*string{aws.String("All")}
+	}
+
+	workerCount := b.workerCount
+	if workerCount <= 0 {
+		workerCount = defaultSqsWorkerCount
+	}
+
+	sub := &sqsSubscription{
+		broker:             b,
+		queue:              queue,
+		queueUrl:           queueUrl,
+		deadLetterQueueUrl: deadLetterQueueUrl,
+		rcvInput:           &rcvInput,
+		handler:            handler,
+		workers:            make(chan struct{}, workerCount),
+		quit:               make(chan struct{}),
+		done:               make(chan struct{}),
+	}
+	go sub.listen()
+	return sub, nil
+}
+
+func (b *sqsBroker) Purge(queue string) error {
+	queueUrl, err := b.getQueueUrl(queue)
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.sqsSrv.PurgeQueue(&sqs.PurgeQueueInput{QueueUrl: queueUrl}); err != nil {
+		return errors.New(fmt.Sprintf("unable to purge aws sqs queue, queue: %s, err: %v", *queueUrl, err))
+	}
+	return nil
+}
+
+func (b *sqsBroker) getQueueUrl(queue string) (*string, error) {
+	urlResult, err := b.sqsSrv.GetQueueUrl(&sqs.GetQueueUrlInput{
 		QueueName: aws.String(queue),
 	})
 	if err != nil {
-		systemlog.Fatalf("unable to get queue url from queue name, name: %s, err:%v", queue, err)
+		return nil, errors.New(fmt.Sprintf("unable to get queue url from queue name, name: %s, err: %v", queue, err))
 	}
+	return urlResult.QueueUrl, nil
+}
 
-	if rcvInput == nil {
-		rcvInput = &sqs.ReceiveMessageInput{}
-	}
-	rcvInput.QueueUrl = urlResult.QueueUrl
+type sqsSubscription struct {
+	broker             *sqsBroker
+	queue              string
+	queueUrl           *string
+	deadLetterQueueUrl *string
+	rcvInput           *sqs.ReceiveMessageInput
+	handler            MsgHandler
+	workers            chan struct{}
+	running            sync.WaitGroup
+	quit               chan struct{}
+	quitOnce           sync.Once
+	done               chan struct{}
+}
 
-	return func() {
-		var rcvOutput *sqs.ReceiveMessageOutput
-		var err error
-		var msg *sqs.Message
+func (s *sqsSubscription) Queue() string {
+	return s.queue
+}
 
-		for {
-			rcvOutput, err = sqsSrv.ReceiveMessage(rcvInput)
-			if err != nil {
-				log.Errorf("some error occurs while pulling from aws sqs, queue: %s, err: %v", *rcvInput.QueueUrl, err)
+// stop polling aws sqs & wait for handlers in worker pool, message not handled yet is redelivered by aws sqs
+func (s *sqsSubscription) Unsubscribe() error {
+	s.quitOnce.Do(func() { close(s.quit) })
+	<-s.done
+	s.running.Wait()
+	return nil
+}
+
+// method listening aws sqs message & handling with handler in bounded worker pool
+// receiving is retried with exponential backoff when aws sqs returns error
+func (s *sqsSubscription) listen() {
+	defer close(s.done)
+	backoff := s.broker.minBackoff
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		default:
+		}
+
+		rcvOutput, err := s.broker.sqsSrv.ReceiveMessage(s.rcvInput)
+		if err != nil {
+			countOutcome(s.queue, outcomeReceiveError)
+			log.Errorf("some error occurs while pulling from aws sqs, retry after %s, queue: %s, err: %v", backoff, *s.queueUrl, err)
+			select {
+			case <-s.quit:
 				return
+			case <-time.After(withJitter(backoff)):
+			}
+			if backoff *= 2; backoff > s.broker.maxBackoff {
+				backoff = s.broker.maxBackoff
 			}
+			continue
+		}
+		backoff = s.broker.minBackoff
 
-			for _, msg = range rcvOutput.Messages {
-				go func(msg *sqs.Message) {
-					if err := handler(msg); err != nil {
-						log.Errorf("some error occurs while handling aws sqs message, queue: %s, msg id: %s err: %v", *rcvInput.QueueUrl, *msg.MessageId, err)
-					}
-					if _, err := sqsSrv.DeleteMessage(&sqs.DeleteMessageInput{
-						QueueUrl:      urlResult.QueueUrl,
-						ReceiptHandle: msg.ReceiptHandle,
-					}); err != nil {
-						log.Errorf("some error occurs while deleting aws sqs message, queue: %s, msg id: %s err: %v", *rcvInput.QueueUrl, *msg.MessageId, err)
-					}
-				} (msg)
+		for _, msg := range rcvOutput.Messages {
+			countOutcome(s.queue, outcomeReceived)
+			select {
+			case s.workers <- struct{}{}:
+			case <-s.quit:
+				return
 			}
+			s.running.Add(1)
+			go func(msg *sqs.Message) {
+				defer s.running.Done()
+				defer func() { <-s.workers }()
+				s.handle(msg)
+			}(msg)
 		}
 	}
 }
 
-// function that returns closure purging (deleting) all message in aws sqs queue
-func SqsQueuePurger(queue string) func() {
-	sqsSrv := sqs.New(awsSession)
-	urlResult, err := sqsSrv.GetQueueUrl(&sqs.GetQueueUrlInput{
-		QueueName: aws.String(queue),
-	})
+// handle message & delete it only when handler succeed, failed message is left for redelivery
+func (s *sqsSubscription) handle(msg *sqs.Message) {
+	if s.exceedMaxReceiveCount(msg) {
+		s.moveToDeadLetterQueue(msg)
+		return
+	}
+
+	stopExtend := s.extendVisibility(msg)
+	err := s.handler(messageFromSqs(msg))
+	close(stopExtend)
+
 	if err != nil {
-		systemlog.Fatalf("unable to get queue url from queue name, name: %s, err:%v", queue, err)
+		countOutcome(s.queue, outcomeFailed)
+		log.Errorf("some error occurs while handling aws sqs message, left for redelivery, queue: %s, msg id: %s err: %v", *s.queueUrl, *msg.MessageId, err)
+		return
 	}
 
-	purgeInput := &sqs.PurgeQueueInput{}
-	purgeInput.QueueUrl = urlResult.QueueUrl
+	countOutcome(s.queue, outcomeSucceeded)
+	s.delete(msg)
+}
 
-	return func() {
-		if _, err := sqsSrv.PurgeQueue(purgeInput); err != nil {
-			log.Errorf("some error occurs while deleting aws sqs message, queue: %s, err: %v", *purgeInput.QueueUrl, err)
+// extend visibility timeout of message periodically until returned channel is closed
+func (s *sqsSubscription) extendVisibility(msg *sqs.Message) chan struct{} {
+	stop := make(chan struct{})
+	interval := s.broker.visibilityTimeout / 2
+	if interval <= 0 {
+		return stop
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if _, err := s.broker.sqsSrv.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
+					QueueUrl:          s.queueUrl,
+					ReceiptHandle:     msg.ReceiptHandle,
+					VisibilityTimeout: aws.Int64(int64(s.broker.visibilityTimeout / time.Second)),
+				}); err != nil {
+					log.Errorf("some error occurs while extending visibility of aws sqs message, queue: %s, msg id: %s err: %v", *s.queueUrl, *msg.MessageId, err)
+				}
+			}
 		}
+	}()
+	return stop
+}
+
+func (s *sqsSubscription) exceedMaxReceiveCount(msg *sqs.Message) bool {
+	if s.broker.maxReceiveCount <= 0 {
+		return false
+	}
+	receiveCount, err := strconv.Atoi(aws.StringValue(msg.Attributes[approximateReceiveCountAttr]))
+	if err != nil {
+		return false
+	}
+	return receiveCount > s.broker.maxReceiveCount
+}
+
+// send message to dead letter queue & delete it from source queue
+func (s *sqsSubscription) moveToDeadLetterQueue(msg *sqs.Message) {
+	if s.deadLetterQueueUrl == nil {
+		countOutcome(s.queue, outcomeDiscarded)
+		log.Errorf("aws sqs message exceed max receive count, discard it, queue: %s, msg id: %s", *s.queueUrl, *msg.MessageId)
+		s.delete(msg)
+		return
+	}
+
+	if _, err := s.broker.sqsSrv.SendMessage(&sqs.SendMessageInput{
+		QueueUrl:          s.deadLetterQueueUrl,
+		MessageBody:       msg.Body,
+		MessageAttributes: msg.MessageAttributes,
+	}); err != nil {
+		log.Errorf("some error occurs while sending aws sqs message to dead letter queue, queue: %s, msg id: %s err: %v", *s.deadLetterQueueUrl, *msg.MessageId, err)
+		return
+	}
+
+	countOutcome(s.queue, outcomeDeadLettered)
+	log.Infof("aws sqs message exceed max receive count, move to dead letter queue, queue: %s, msg id: %s", *s.queueUrl, *msg.MessageId)
+	s.delete(msg)
+}
+
+func (s *sqsSubscription) delete(msg *sqs.Message) {
+	if _, err := s.broker.sqsSrv.DeleteMessage(&sqs.DeleteMessageInput{
+		QueueUrl:      s.queueUrl,
+		ReceiptHandle: msg.ReceiptHandle,
+	}); err != nil {
+		countOutcome(s.queue, outcomeDeleteFailed)
+		log.Errorf("some error occurs while deleting aws sqs message, queue: %s, msg id: %s err: %v", *s.queueUrl, *msg.MessageId, err)
+	}
+}
+
+// convert aws sqs message to broker-agnostic Message
+func messageFromSqs(msg *sqs.Message) *Message {
+	converted := &Message{
+		ID:         aws.StringValue(msg.MessageId),
+		Body:       []byte(aws.StringValue(msg.Body)),
+		Attributes: map[string]string{},
+	}
+	for key, value := range msg.Attributes {
+		converted.Attributes[key] = aws.StringValue(value)
+	}
+	for key, value := range msg.MessageAttributes {
+		if value != nil && value.StringValue != nil {
+			converted.Attributes[key] = *value.StringValue
+		}
+	}
+	return converted
+}
+
+// return duration added random jitter up to half of parameter
+func withJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
 	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
 }
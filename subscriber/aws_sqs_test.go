@@ -0,0 +1,281 @@
+package subscriber
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fake aws sqs client returning scripted results of ReceiveMessage & recording other calls
+type fakeSqsClient struct {
+	sqsiface.SQSAPI
+	mutex             sync.Mutex
+	receives          []fakeReceive
+	receiveTimes      []time.Time
+	deleted           []string
+	visibilityChanged []string
+	sent              []*sqs.SendMessageInput
+}
+
+type fakeReceive struct {
+	messages []*sqs.Message
+	err      error
+}
+
+func (c *fakeSqsClient) GetQueueUrl(input *sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error) {
+	return &sqs.GetQueueUrlOutput{QueueUrl: aws.String("https://sqs.local/" + *input.QueueName)}, nil
+}
+
+// return scripted result in order, or empty result after short wait like long polling if there is no more result
+func (c *fakeSqsClient) ReceiveMessage(*sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	c.mutex.Lock()
+	c.receiveTimes = append(c.receiveTimes, time.Now())
+	if len(c.receives) == 0 {
+		c.mutex.Unlock()
+		time.Sleep(time.Millisecond * 5)
+		return &sqs.ReceiveMessageOutput{}, nil
+	}
+	receive := c.receives[0]
+	c.receives = c.receives[1:]
+	c.mutex.Unlock()
+
+	if receive.err != nil {
+		return nil, receive.err
+	}
+	return &sqs.ReceiveMessageOutput{Messages: receive.messages}, nil
+}
+
+func (c *fakeSqsClient) DeleteMessage(input *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.deleted = append(c.deleted, *input.ReceiptHandle)
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (c *fakeSqsClient) ChangeMessageVisibility(input *sqs.ChangeMessageVisibilityInput) (*sqs.ChangeMessageVisibilityOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.visibilityChanged = append(c.visibilityChanged, *input.ReceiptHandle)
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func (c *fakeSqsClient) SendMessage(input *sqs.SendMessageInput) (*sqs.SendMessageOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sent = append(c.sent, input)
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func (c *fakeSqsClient) deletedCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.deleted)
+}
+
+func newFakeSqsBroker(client *fakeSqsClient, setters ...SqsFieldSetter) *sqsBroker {
+	b := &sqsBroker{
+		sqsSrv:            client,
+		workerCount:       defaultSqsWorkerCount,
+		visibilityTimeout: defaultSqsVisibilityTimeout,
+		minBackoff:        defaultSqsMinBackoff,
+		maxBackoff:        defaultSqsMaxBackoff,
+	}
+	for _, setter := range setters {
+		setter(b)
+	}
+	return b
+}
+
+func newSqsMessage(id string, receiveCount string) *sqs.Message {
+	return &sqs.Message{
+		MessageId:     aws.String(id),
+		ReceiptHandle: aws.String("receipt-" + id),
+		Body:          aws.String(`{"type": "test"}`),
+		Attributes:    map[string]*string{approximateReceiveCountAttr: aws.String(receiveCount)},
+	}
+}
+
+// wait until condition is satisfied, fail test if it isn't satisfied in a second
+func waitUntil(t *testing.T, condition func() bool, message string) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.Fail(t, message)
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func Test_sqsBroker_Subscribe_AttributeNamesNotShared(t *testing.T) {
+	client := &fakeSqsClient{}
+	broker := newFakeSqsBroker(client)
+	// template with spare capacity, appending to it without copy makes subscriptions share backing array
+	broker.rcvInput.AttributeNames = append(make([]*string, 0, 4), aws.String("SentTimestamp"))
+
+	first, err := broker.Subscribe("first-queue", func(*Message) error { return nil })
+	assert.Nil(t, err)
+	second, err := broker.Subscribe("second-queue", func(*Message) error { return nil })
+	assert.Nil(t, err)
+	defer func() { _ = first.Unsubscribe(); _ = second.Unsubscribe() }()
+
+	firstNames, secondNames := first.(*sqsSubscription).rcvInput.AttributeNames, second.(*sqsSubscription).rcvInput.AttributeNames
+	assert.Equal(t, []string{"SentTimestamp", approximateReceiveCountAttr}, aws.StringValueSlice(firstNames))
+	assert.Equal(t, []string{"SentTimestamp", approximateReceiveCountAttr}, aws.StringValueSlice(secondNames))
+	assert.NotSame(t, firstNames[1], secondNames[1])
+	assert.Len(t, broker.rcvInput.AttributeNames, 1, "template of ReceiveMessageInput must not be changed")
+}
+
+func Test_sqsSubscription_ReceiveBackoff(t *testing.T) {
+	receiveErr := errors.New("service unavailable")
+	client := &fakeSqsClient{receives: []fakeReceive{
+		{err: receiveErr}, {err: receiveErr}, {err: receiveErr}, {err: receiveErr},
+		{messages: []*sqs.Message{newSqsMessage("1", "1")}},
+	}}
+	broker := newFakeSqsBroker(client, SqsBackoff(time.Millisecond*10, time.Millisecond*20))
+
+	sub, err := broker.Subscribe("queue", func(*Message) error { return nil })
+	assert.Nil(t, err)
+	waitUntil(t, func() bool { return client.deletedCount() == 1 }, "message must be handled after receiving is recovered")
+	assert.Nil(t, sub.Unsubscribe())
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	// backoff is doubled in each failure up to max backoff, jitter only adds to backoff
+	expectedMinGaps := []time.Duration{time.Millisecond * 10, time.Millisecond * 20, time.Millisecond * 20, time.Millisecond * 20}
+	for i, expected := range expectedMinGaps {
+		gap := client.receiveTimes[i+1].Sub(client.receiveTimes[i])
+		assert.Truef(t, gap >= expected, "receiving must be retried after backoff, index: %d, gap: %s, expected: %s", i, gap, expected)
+		assert.Truef(t, gap < expected*2+time.Millisecond*50, "backoff must be capped by max backoff, index: %d, gap: %s", i, gap)
+	}
+}
+
+func Test_sqsSubscription_WorkerPool(t *testing.T) {
+	var messages []*sqs.Message
+	for _, id := range []string{"1", "2", "3", "4", "5", "6"} {
+		messages = append(messages, newSqsMessage(id, "1"))
+	}
+	client := &fakeSqsClient{receives: []fakeReceive{{messages: messages}}}
+	broker := newFakeSqsBroker(client, SqsWorkerCount(2))
+
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+	release := make(chan struct{})
+	sub, err := broker.Subscribe("queue", func(*Message) error {
+		mutex.Lock()
+		if running++; running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		<-release
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		return nil
+	})
+	assert.Nil(t, err)
+
+	waitUntil(t, func() bool { mutex.Lock(); defer mutex.Unlock(); return running == 2 }, "handlers must run concurrently up to worker count")
+	time.Sleep(time.Millisecond * 20)
+	close(release)
+
+	waitUntil(t, func() bool { return client.deletedCount() == len(messages) }, "all messages must be handled")
+	assert.Nil(t, sub.Unsubscribe())
+	assert.Equal(t, 2, maxRunning, "handlers running at the same time must not exceed worker count")
+}
+
+func Test_sqsSubscription_ExtendVisibility(t *testing.T) {
+	client := &fakeSqsClient{receives: []fakeReceive{{messages: []*sqs.Message{newSqsMessage("1", "1")}}}}
+	broker := newFakeSqsBroker(client, SqsVisibilityTimeout(time.Millisecond*20))
+
+	sub, err := broker.Subscribe("queue", func(*Message) error {
+		time.Sleep(time.Millisecond * 60)
+		return nil
+	})
+	assert.Nil(t, err)
+	waitUntil(t, func() bool { return client.deletedCount() == 1 }, "message must be handled")
+	assert.Nil(t, sub.Unsubscribe())
+
+	client.mutex.Lock()
+	extended := len(client.visibilityChanged)
+	client.mutex.Unlock()
+	assert.True(t, extended >= 2, "visibility must be extended periodically while handler is running, extended: %d", extended)
+	for _, receipt := range client.visibilityChanged {
+		assert.Equal(t, "receipt-1", receipt)
+	}
+
+	// extending is stopped after handler returns
+	time.Sleep(time.Millisecond * 30)
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	assert.Equal(t, extended, len(client.visibilityChanged), "visibility must not be extended after handler returns")
+}
+
+func Test_sqsSubscription_DeadLetterQueue(t *testing.T) {
+	tests := []struct {
+		ReceiveCount      string
+		DeadLetterQueue   string
+		HandlerErr        error
+		ExpectedHandled   bool
+		ExpectedSentToDLQ bool
+		ExpectedDeleted   bool
+	}{
+		{ // receive count exceeds max -> moved to dead letter queue without handling
+			ReceiveCount:      "4",
+			DeadLetterQueue:   "queue-dlq",
+			ExpectedSentToDLQ: true,
+			ExpectedDeleted:   true,
+		}, { // receive count exceeds max without dead letter queue -> discarded
+			ReceiveCount:    "4",
+			ExpectedDeleted: true,
+		}, { // receive count doesn't exceed max -> handled & deleted
+			ReceiveCount:    "3",
+			DeadLetterQueue: "queue-dlq",
+			ExpectedHandled: true,
+			ExpectedDeleted: true,
+		}, { // handler fails -> left for redelivery
+			ReceiveCount:    "1",
+			DeadLetterQueue: "queue-dlq",
+			HandlerErr:      errors.New("unexpected error"),
+			ExpectedHandled: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		client := &fakeSqsClient{receives: []fakeReceive{{messages: []*sqs.Message{newSqsMessage("1", testCase.ReceiveCount)}}}}
+		broker := newFakeSqsBroker(client, SqsDeadLetterQueue(testCase.DeadLetterQueue, 3))
+
+		var mutex sync.Mutex
+		handledCount := 0
+		sub, err := broker.Subscribe("queue", func(*Message) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			handledCount++
+			return testCase.HandlerErr
+		})
+		assert.Nil(t, err)
+
+		if testCase.ExpectedDeleted {
+			waitUntil(t, func() bool { return client.deletedCount() == 1 }, "message must be deleted from source queue")
+		} else {
+			waitUntil(t, func() bool { mutex.Lock(); defer mutex.Unlock(); return handledCount == 1 }, "message must be handled")
+		}
+		assert.Nil(t, sub.Unsubscribe())
+
+		assert.Equalf(t, testCase.ExpectedHandled, handledCount == 1, "handled assertion error (test case: %v)", testCase)
+		client.mutex.Lock()
+		assert.Equalf(t, testCase.ExpectedSentToDLQ, len(client.sent) == 1, "dead letter assertion error (test case: %v)", testCase)
+		if testCase.ExpectedSentToDLQ {
+			assert.Equal(t, "https://sqs.local/queue-dlq", *client.sent[0].QueueUrl)
+			assert.Equal(t, `{"type": "test"}`, *client.sent[0].MessageBody)
+		}
+		assert.Equalf(t, testCase.ExpectedDeleted, len(client.deleted) == 1, "delete assertion error (test case: %v)", testCase)
+		client.mutex.Unlock()
+	}
+}
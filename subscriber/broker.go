@@ -0,0 +1,29 @@
+// broker.go is file that declare interface abstracting message broker like aws sqs, in-memory channel, etc ...
+// default subscriber only depends on these interfaces, so that it can run without specific broker like aws
+
+package subscriber
+
+// Message is broker-agnostic message struct passed to MsgHandler
+type Message struct {
+	ID         string
+	Body       []byte
+	Attributes map[string]string
+}
+
+// function signature type for message handler registered in subscriber
+type MsgHandler func(*Message) error
+
+// Broker is interface for message broker that can subscribe & purge queue
+type Broker interface {
+	// method to start consuming queue with handler, consuming is continued until Unsubscribe is called
+	Subscribe(queue string, handler MsgHandler) (Subscription, error)
+	// method to purge (delete) all message in queue
+	Purge(queue string) error
+}
+
+// Subscription is interface for handle of consuming queue returned from Broker.Subscribe
+type Subscription interface {
+	Queue() string
+	// method to stop consuming queue, it returns after running handlers are finished
+	Unsubscribe() error
+}
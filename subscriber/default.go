@@ -1,24 +1,19 @@
 // Add package in v.1.0.5
 // subscriber package is used for handling event message occurred by SNS, RabbitMQ, etc ...
-// you can start subscribe by calling Start method with parameter, specific signature function
+// you can start subscribe by registering handler per queue and calling StartListening method
 
 package subscriber
 
 import (
-	"github.com/aws/aws-sdk-go/aws/session"
+	"errors"
 	log "github.com/micro/go-micro/v2/logger"
 )
 
-var awsSession *session.Session
-
-func SetAwsSession(s *session.Session) {
-	awsSession = s
-}
-
 type _default struct {
-	awsSession  *session.Session
-	listeners   []func()
-	beforeStart []func()
+	broker        Broker
+	handlers      map[string]MsgHandler
+	subscriptions []Subscription
+	beforeStart   []func()
 }
 
 type FieldSetter func(*_default)
@@ -29,39 +24,74 @@ func Default(setters ...FieldSetter) *_default {
 
 func newDefault(setters ...FieldSetter) (h *_default) {
 	h = new(_default)
-	h.awsSession = awsSession
 	for _, setter := range setters {
 		setter(h)
 	}
-	h.listeners = []func(){}
+	h.handlers = map[string]MsgHandler{}
+	h.subscriptions = []Subscription{}
 	h.beforeStart = []func(){}
 	return
 }
 
-func AwsSession(awsSession *session.Session) FieldSetter {
+func MessageBroker(b Broker) FieldSetter {
 	return func(s *_default) {
-		s.awsSession = awsSession
+		s.broker = b
 	}
 }
 
-// function that register listeners to run in StartListening method
-func (s *_default) RegisterListeners(fn ...func()) {
-	s.listeners = append(s.listeners, fn...)
+// function that register handler to subscribe queue in StartListening method
+func (s *_default) RegisterHandler(queue string, handler MsgHandler) {
+	s.handlers[queue] = handler
 }
 
 func (s *_default) RegisterBeforeStart(fn ...func()) {
 	s.beforeStart = append(s.beforeStart, fn...)
 }
 
-// function that start listening with listeners that register in RegisterListeners method
-func (s *_default) StartListening() (_ error) {
+// function that returns closure purging all message in queue, used with RegisterBeforeStart method
+func (s *_default) QueuePurger(queue string) func() {
+	return func() {
+		if err := s.broker.Purge(queue); err != nil {
+			log.Errorf("some error occurs while purging queue, queue: %s, err: %v", queue, err)
+		}
+	}
+}
+
+// function that start listening with handlers that register in RegisterHandler method
+func (s *_default) StartListening() (err error) {
+	if s.broker == nil {
+		err = errors.New("message broker is not set, please set with subscriber.MessageBroker")
+		return
+	}
+
 	for _, before := range s.beforeStart {
 		before()
 	}
-	
+
+	for queue, handler := range s.handlers {
+		sub, subErr := s.broker.Subscribe(queue, handler)
+		if subErr != nil {
+			log.Errorf("unable to subscribe queue, queue: %s, err: %v", queue, subErr)
+			err = subErr
+			continue
+		}
+		s.subscriptions = append(s.subscriptions, sub)
+	}
+
 	log.Info("Default subscriber start listening!!")
-	for _, listener := range s.listeners {
-		go listener()
+	return
+}
+
+// function that stop polling of subscriptions started in StartListening method & wait for running handlers
+func (s *_default) StopListening() (err error) {
+	for _, sub := range s.subscriptions {
+		if unsubErr := sub.Unsubscribe(); unsubErr != nil {
+			log.Errorf("unable to unsubscribe queue, queue: %s, err: %v", sub.Queue(), unsubErr)
+			err = unsubErr
+		}
 	}
+	s.subscriptions = []Subscription{}
+
+	log.Info("Default subscriber stop listening!!")
 	return
 }
@@ -0,0 +1,110 @@
+// memory.go is file that declare Broker implementation using in-memory channel
+// it is used for testing event handler end to end or running service without aws
+
+package subscriber
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/micro/go-micro/v2/logger"
+	"sync"
+)
+
+const defaultMemoryQueueSize = 100
+
+type memoryBroker struct {
+	queues     map[string]chan *Message
+	queueSize  int
+	queueMutex sync.Mutex
+}
+
+// function that returns Broker implementation using in-memory channel per queue
+func MemoryBroker() *memoryBroker {
+	return &memoryBroker{
+		queues:    map[string]chan *Message{},
+		queueSize: defaultMemoryQueueSize,
+	}
+}
+
+// method to publish message in queue, it returns error if queue is full
+func (b *memoryBroker) Publish(queue string, msg *Message) error {
+	select {
+	case b.getQueue(queue) <- msg:
+		return nil
+	default:
+		return errors.New(fmt.Sprintf("in-memory queue is full, queue: %s", queue))
+	}
+}
+
+func (b *memoryBroker) Subscribe(queue string, handler MsgHandler) (Subscription, error) {
+	sub := &memorySubscription{
+		queue:   queue,
+		msgChan: b.getQueue(queue),
+		handler: handler,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go sub.listen()
+	return sub, nil
+}
+
+func (b *memoryBroker) Purge(queue string) error {
+	msgChan := b.getQueue(queue)
+	for {
+		select {
+		case <-msgChan:
+		default:
+			return nil
+		}
+	}
+}
+
+// get channel of queue, create new one if not exist
+func (b *memoryBroker) getQueue(queue string) chan *Message {
+	b.queueMutex.Lock()
+	defer b.queueMutex.Unlock()
+
+	if _, exist := b.queues[queue]; !exist {
+		b.queues[queue] = make(chan *Message, b.queueSize)
+	}
+	return b.queues[queue]
+}
+
+type memorySubscription struct {
+	queue    string
+	msgChan  chan *Message
+	handler  MsgHandler
+	quit     chan struct{}
+	quitOnce sync.Once
+	done     chan struct{}
+}
+
+func (s *memorySubscription) Queue() string {
+	return s.queue
+}
+
+// stop receiving message from channel & wait for handler running now
+func (s *memorySubscription) Unsubscribe() error {
+	s.quitOnce.Do(func() { close(s.quit) })
+	<-s.done
+	return nil
+}
+
+// method receiving message from channel & handling with handler received from Subscribe
+func (s *memorySubscription) listen() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.quit:
+			return
+		case msg := <-s.msgChan:
+			countOutcome(s.queue, outcomeReceived)
+			if err := s.handler(msg); err != nil {
+				countOutcome(s.queue, outcomeFailed)
+				log.Errorf("some error occurs while handling in-memory message, queue: %s, msg id: %s err: %v", s.queue, msg.ID, err)
+				continue
+			}
+			countOutcome(s.queue, outcomeSucceeded)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// metrics.go is file that declare metrics about processing outcome of message, exported through expvar
+
+package subscriber
+
+import (
+	"expvar"
+	"fmt"
+)
+
+const (
+	outcomeReceived     = "received"
+	outcomeSucceeded    = "succeeded"
+	outcomeFailed       = "failed"
+	outcomeDeadLettered = "dead_lettered"
+	outcomeDiscarded    = "discarded"
+	outcomeDeleteFailed = "delete_failed"
+	outcomeReceiveError = "receive_error"
+	outcomePoisoned     = "poisoned"
+	outcomeUnknownEvent = "unknown_event"
+)
+
+// processing outcome count per queue, key format is "<queue>.<outcome>"
+var outcomeMetrics = expvar.NewMap("subscriber_outcomes")
+
+func countOutcome(queue, outcome string) {
+	outcomeMetrics.Add(fmt.Sprintf("%s.%s", queue, outcome), 1)
+}
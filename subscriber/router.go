@@ -0,0 +1,107 @@
+// router.go is file that declare router decoding message envelope & dispatching it to handler registered per event type
+// unknown event type is logged and acknowledged, malformed message is passed to poison message handler
+
+package subscriber
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/micro/go-micro/v2/logger"
+)
+
+const routerMetricsKey = "router"
+
+// Envelope is common format of event message body
+type Envelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// function signature type for event handler registered in router
+type EventHandler func(*Envelope) error
+
+// function signature type for handler called with message that can never be handled successfully
+type PoisonHandler func(msg *Message, reason error)
+
+type malformedPayloadError struct {
+	reason string
+}
+
+func (e *malformedPayloadError) Error() string {
+	return "malformed payload, reason: " + e.reason
+}
+
+// function that returns error meaning payload of event is malformed, event handler should return it to pass message to poison path
+func MalformedPayload(reason string) error {
+	return &malformedPayloadError{reason: reason}
+}
+
+type router struct {
+	handlers map[string]EventHandler
+	poison   PoisonHandler
+}
+
+type RouterFieldSetter func(*router)
+
+func Router(setters ...RouterFieldSetter) *router {
+	r := &router{
+		handlers: map[string]EventHandler{},
+		poison:   logPoisonMessage,
+	}
+	for _, setter := range setters {
+		setter(r)
+	}
+	return r
+}
+
+func Poison(h PoisonHandler) RouterFieldSetter {
+	return func(r *router) {
+		r.poison = h
+	}
+}
+
+// method to register event handler for event type
+func (r *router) Handle(eventType string, handler EventHandler) {
+	r.handlers[eventType] = handler
+}
+
+// method that decode envelope from message & dispatch to event handler, used as MsgHandler
+// returning nil means acknowledgement, so error is returned only when message should be redelivered
+func (r *router) Route(msg *Message) error {
+	envelope := new(Envelope)
+	if err := json.Unmarshal(msg.Body, envelope); err != nil {
+		r.passToPoison(msg, errors.New(fmt.Sprintf("unable to decode message envelope, err: %v", err)))
+		return nil
+	}
+	if envelope.Type == "" {
+		r.passToPoison(msg, errors.New("event type is not set in message envelope"))
+		return nil
+	}
+
+	handler, exist := r.handlers[envelope.Type]
+	if !exist {
+		countOutcome(routerMetricsKey, outcomeUnknownEvent)
+		log.Infof("event handler is not registered, acknowledge message, type: %s, msg id: %s", envelope.Type, msg.ID)
+		return nil
+	}
+
+	err := handler(envelope)
+	var malformedErr *malformedPayloadError
+	if errors.As(err, &malformedErr) {
+		r.passToPoison(msg, err)
+		return nil
+	}
+	return err
+}
+
+func (r *router) passToPoison(msg *Message, reason error) {
+	countOutcome(routerMetricsKey, outcomePoisoned)
+	r.poison(msg, reason)
+}
+
+// default poison message handler, just logging message
+func logPoisonMessage(msg *Message, reason error) {
+	log.Errorf("poison message received, msg id: %s, body: %s, reason: %v", msg.ID, string(msg.Body), reason)
+}
@@ -0,0 +1,92 @@
+package subscriber
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_router_Route(t *testing.T) {
+	handlerErr := errors.New("temporary error")
+
+	tests := []struct {
+		Body            string
+		HandlerErr      error
+		ExpectedHandled bool
+		ExpectedPoison  bool
+		ExpectedErr     error
+	}{
+		{ // registered event type -> dispatched to event handler
+			Body:            `{"type": "ChangeConsulNodes", "version": 1, "payload": {}}`,
+			ExpectedHandled: true,
+		}, { // unknown event type -> acknowledged without handler & poison handler
+			Body: `{"type": "UnknownEvent", "version": 1, "payload": {}}`,
+		}, { // not json body -> passed to poison handler & acknowledged
+			Body:           `not json`,
+			ExpectedPoison: true,
+		}, { // event type not set -> passed to poison handler & acknowledged
+			Body:           `{"version": 1, "payload": {}}`,
+			ExpectedPoison: true,
+		}, { // handler returns malformed payload -> passed to poison handler & acknowledged
+			Body:            `{"type": "ChangeConsulNodes", "version": 1, "payload": {}}`,
+			HandlerErr:      MalformedPayload("unexpected field"),
+			ExpectedHandled: true,
+			ExpectedPoison:  true,
+		}, { // handler returns other error -> returned for redelivery
+			Body:            `{"type": "ChangeConsulNodes", "version": 1, "payload": {}}`,
+			HandlerErr:      handlerErr,
+			ExpectedHandled: true,
+			ExpectedErr:     handlerErr,
+		},
+	}
+
+	for _, testCase := range tests {
+		handled, poisoned := false, false
+		r := Router(Poison(func(*Message, error) { poisoned = true }))
+		r.Handle("ChangeConsulNodes", func(envelope *Envelope) error {
+			handled = true
+			return testCase.HandlerErr
+		})
+
+		err := r.Route(&Message{ID: "msg-1", Body: []byte(testCase.Body)})
+		assert.Equalf(t, testCase.ExpectedErr, err, "error assertion error (test case: %v)", testCase)
+		assert.Equalf(t, testCase.ExpectedHandled, handled, "handled assertion error (test case: %v)", testCase)
+		assert.Equalf(t, testCase.ExpectedPoison, poisoned, "poison assertion error (test case: %v)", testCase)
+	}
+}
+
+func Test_default_StopListening_WaitInFlightHandler(t *testing.T) {
+	broker := MemoryBroker()
+	started, release := make(chan struct{}), make(chan struct{})
+
+	r := Router()
+	r.Handle("ChangeConsulNodes", func(*Envelope) error {
+		close(started)
+		<-release
+		return nil
+	})
+	s := Default(MessageBroker(broker))
+	s.RegisterHandler("queue", r.Route)
+	assert.Nil(t, s.StartListening())
+
+	assert.Nil(t, broker.Publish("queue", &Message{ID: "msg-1", Body: []byte(`{"type": "ChangeConsulNodes"}`)}))
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.StopListening() }()
+
+	select {
+	case <-stopped:
+		assert.Fail(t, "StopListening must wait for in-flight handler")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	close(release)
+	select {
+	case err := <-stopped:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "StopListening must return after in-flight handler is finished")
+	}
+}
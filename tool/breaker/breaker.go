@@ -0,0 +1,84 @@
+// breaker package in tool dir is used for circuit breaker counting failure per key like node address
+// breaker.go is file to declare circuit breaker which is tripped after consecutive failures & recovered after open duration
+
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type state struct {
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+type breaker struct {
+	threshold    int
+	openDuration time.Duration
+	states       map[string]*state
+	mutex        sync.Mutex
+}
+
+// function that returns circuit breaker tripped after threshold consecutive failures & opened during openDuration
+func New(threshold int, openDuration time.Duration) *breaker {
+	return &breaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+		states:       map[string]*state{},
+	}
+}
+
+// method that returns if call to key is allowed, only one call is allowed as trial after open duration (half-open)
+// other calls are not allowed until result of trial call is recorded with Success or Failure
+func (b *breaker) Allow(key string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	s, exist := b.states[key]
+	switch {
+	case !exist || s.openUntil.IsZero():
+		return true
+	case time.Now().Before(s.openUntil) || s.probing:
+		return false
+	default:
+		s.probing = true
+		return true
+	}
+}
+
+// method to record success of call, it closes breaker of key
+func (b *breaker) Success(key string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.states, key)
+}
+
+// method to record failure of call, it returns true if breaker of key is tripped by this failure
+func (b *breaker) Failure(key string) (tripped bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	s, exist := b.states[key]
+	if !exist {
+		s = &state{}
+		b.states[key] = s
+	}
+
+	// failure of trial call after open duration trips breaker again immediately
+	s.failures++
+	if s.failures >= b.threshold || !s.openUntil.IsZero() {
+		s.openUntil = time.Now().Add(b.openDuration)
+		s.failures = 0
+		s.probing = false
+		tripped = true
+	}
+	return
+}
+
+// method that returns open duration of breaker
+func (b *breaker) OpenDuration() time.Duration {
+	return b.openDuration
+}
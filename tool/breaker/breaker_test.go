@@ -0,0 +1,64 @@
+package breaker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const testKey = "127.0.0.1:10101"
+
+func Test_breaker_TripAtThreshold(t *testing.T) {
+	b := New(3, time.Minute)
+
+	assert.False(t, b.Failure(testKey))
+	assert.False(t, b.Failure(testKey))
+	assert.True(t, b.Allow(testKey), "breaker must be closed under threshold")
+
+	// success resets consecutive failures
+	b.Success(testKey)
+	assert.False(t, b.Failure(testKey))
+	assert.False(t, b.Failure(testKey))
+	assert.True(t, b.Allow(testKey))
+
+	assert.True(t, b.Failure(testKey), "breaker must be tripped at threshold")
+	assert.False(t, b.Allow(testKey), "breaker must be open during open duration")
+	assert.True(t, b.Allow("127.0.0.1:10102"), "breaker of other key must not be affected")
+}
+
+func Test_breaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		ProbeSucceed    bool
+		ExpectedTripped bool
+		ExpectedAllow   bool
+	}{
+		{ // trial call succeeds -> breaker is closed
+			ProbeSucceed:  true,
+			ExpectedAllow: true,
+		}, { // trial call fails -> breaker is tripped again immediately
+			ProbeSucceed:    false,
+			ExpectedTripped: true,
+			ExpectedAllow:   false,
+		},
+	}
+
+	for _, testCase := range tests {
+		b := New(2, time.Millisecond*20)
+		b.Failure(testKey)
+		assert.True(t, b.Failure(testKey))
+		assert.False(t, b.Allow(testKey))
+
+		// only one trial call is allowed after open duration
+		time.Sleep(time.Millisecond * 30)
+		assert.Truef(t, b.Allow(testKey), "trial call must be allowed after open duration (test case: %v)", testCase)
+		assert.Falsef(t, b.Allow(testKey), "only one trial call must be allowed in half-open (test case: %v)", testCase)
+
+		if testCase.ProbeSucceed {
+			b.Success(testKey)
+		} else {
+			assert.Equalf(t, testCase.ExpectedTripped, b.Failure(testKey), "tripped assertion error (test case: %v)", testCase)
+		}
+		assert.Equalf(t, testCase.ExpectedAllow, b.Allow(testKey), "allow assertion error (test case: %v)", testCase)
+		assert.Equalf(t, testCase.ExpectedAllow, b.Allow(testKey), "allow assertion error (test case: %v)", testCase)
+	}
+}
@@ -0,0 +1,50 @@
+// graceful package in tool dir is used for shutting down service gracefully, like draining rpc, closing resources in order, etc ...
+// drain.go is file to declare handler wrapper tracking in-flight rpc & rejecting new rpc while draining
+
+package graceful
+
+import (
+	"context"
+	microerrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/server"
+	"net/http"
+	"sync"
+)
+
+type rpcDrainer struct {
+	inFlight sync.WaitGroup
+	mutex    sync.RWMutex
+	draining bool
+}
+
+func RPCDrainer() *rpcDrainer {
+	return &rpcDrainer{}
+}
+
+// method that returns handler wrapper to register with micro.WrapHandler
+func (d *rpcDrainer) HandlerWrapper() server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			d.mutex.RLock()
+			if d.draining {
+				d.mutex.RUnlock()
+				return microerrors.New(req.Service(), "service is shutting down", http.StatusServiceUnavailable)
+			}
+			d.inFlight.Add(1)
+			d.mutex.RUnlock()
+
+			defer d.inFlight.Done()
+			return fn(ctx, req, rsp)
+		}
+	}
+}
+
+// method that reject new rpc & wait for in-flight rpc to be finished
+func (d *rpcDrainer) Drain() error {
+	d.mutex.Lock()
+	d.draining = true
+	d.mutex.Unlock()
+
+	d.inFlight.Wait()
+	return nil
+}
@@ -0,0 +1,58 @@
+package graceful
+
+import (
+	"context"
+	microerrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/server"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeRequest struct {
+	server.Request
+}
+
+func (fakeRequest) Service() string {
+	return "DMS.SMS.v1.service.club"
+}
+
+func Test_rpcDrainer_Drain(t *testing.T) {
+	drainer := RPCDrainer()
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := drainer.HandlerWrapper()(func(context.Context, server.Request, interface{}) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	handled := make(chan error, 1)
+	go func() { handled <- handler(context.Background(), fakeRequest{}, nil) }()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- drainer.Drain() }()
+
+	select {
+	case <-drained:
+		assert.Fail(t, "Drain must wait for in-flight rpc")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	// new rpc is rejected while draining
+	err := drainer.HandlerWrapper()(func(context.Context, server.Request, interface{}) error {
+		assert.Fail(t, "handler must not be called while draining")
+		return nil
+	})(context.Background(), fakeRequest{}, nil)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), microerrors.Parse(err.Error()).Code)
+
+	close(release)
+	assert.Nil(t, <-handled)
+	select {
+	case err := <-drained:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Drain must return after in-flight rpc is finished")
+	}
+}
@@ -0,0 +1,63 @@
+// sequence.go is file to declare sequence running shutdown steps in order within deadline
+
+package graceful
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/micro/go-micro/v2/logger"
+	"time"
+)
+
+type step struct {
+	name string
+	fn   func() error
+}
+
+type sequence struct {
+	timeout time.Duration
+	steps   []step
+}
+
+// function that returns sequence whose steps must be finished within timeout
+func Sequence(timeout time.Duration) *sequence {
+	return &sequence{timeout: timeout}
+}
+
+// method to add step run after previously added steps
+func (s *sequence) Add(name string, fn func() error) *sequence {
+	s.steps = append(s.steps, step{name: name, fn: fn})
+	return s
+}
+
+// method to run steps in order, used with micro.BeforeStop
+// remaining steps are skipped if deadline is exceeded while waiting some step
+func (s *sequence) Run() (err error) {
+	deadline := time.After(s.timeout)
+
+	for index, step := range s.steps {
+		done := make(chan error, 1)
+		go func(fn func() error) {
+			done <- fn()
+		}(step.fn)
+
+		select {
+		case stepErr := <-done:
+			if stepErr != nil {
+				log.Errorf("error occurs while shutting down %s, err: %v", step.name, stepErr)
+				err = stepErr
+				continue
+			}
+			log.Infof("succeed to shut down %s", step.name)
+		case <-deadline:
+			var skipped []string
+			for _, remain := range s.steps[index:] {
+				skipped = append(skipped, remain.name)
+			}
+			err = errors.New(fmt.Sprintf("shutdown deadline (%s) exceeded, skipped steps: %v", s.timeout, skipped))
+			log.Error(err)
+			return
+		}
+	}
+	return
+}
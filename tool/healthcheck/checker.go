@@ -0,0 +1,64 @@
+// healthcheck package is used for checking health of dependencies & exposing it to consul with HTTP endpoint
+// checker.go is file to declare checkers implementing health.ICheckable, used with go-health
+
+package healthcheck
+
+import (
+	"club/consul"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"net"
+	"time"
+)
+
+type s3BucketChecker struct {
+	awsSession *session.Session
+	bucket     func() string
+}
+
+// return checker reporting failure if S3 bucket is not accessible with aws session
+// bucket is function returning bucket name, so that bucket can be changed in runtime configuration
+func S3Bucket(awsSession *session.Session, bucket func() string) *s3BucketChecker {
+	return &s3BucketChecker{awsSession: awsSession, bucket: bucket}
+}
+
+func (c *s3BucketChecker) Status() (interface{}, error) {
+	bucket := c.bucket()
+	if _, err := s3.New(c.awsSession).HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to access S3 bucket, bucket: %s, err: %v", bucket, err))
+	}
+	return nil, nil
+}
+
+type serviceReachableChecker struct {
+	consulAgent consul.Agent
+	service     consul.ServiceName
+	timeout     time.Duration
+}
+
+// return checker reporting failure if node of service selected in consul agent is not reachable with TCP
+// result of dial is reported to consul agent, so that it is used in node selection strategy
+func ServiceReachable(consulAgent consul.Agent, service consul.ServiceName, timeout time.Duration) *serviceReachableChecker {
+	return &serviceReachableChecker{consulAgent: consulAgent, service: service, timeout: timeout}
+}
+
+func (c *serviceReachableChecker) Status() (interface{}, error) {
+	node, err := c.consulAgent.GetNextServiceNode(c.service)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to select node of service, service: %s, err: %v", c.service, err))
+	}
+
+	// dial is reported as call started & finished, so that outstanding count of node is not left decreased
+	c.consulAgent.StartCall(c.service, node.Address)
+	startTime := time.Now()
+	conn, err := net.DialTimeout("tcp", node.Address, c.timeout)
+	c.consulAgent.ReportCallResult(c.service, node.Address, time.Since(startTime), err)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to reach node of service, service: %s, address: %s, err: %v", c.service, node.Address, err))
+	}
+	_ = conn.Close()
+	return map[string]string{"address": node.Address}, nil
+}
@@ -0,0 +1,66 @@
+// server.go is file to declare HTTP server exposing result of health checks, registered as consul HTTP check
+// GET /health returns 200 if all checks are passing, otherwise 500 (critical in consul)
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"github.com/InVisionApp/go-health/v2"
+	"github.com/InVisionApp/go-health/v2/handlers"
+	log "github.com/micro/go-micro/v2/logger"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	Path               = "/health"
+	defaultStopTimeout = time.Second * 5
+)
+
+type server struct {
+	port       int
+	httpServer *http.Server
+}
+
+// return HTTP server serving result of checks in h on port, metrics in expvar are also served in /debug/vars
+func Server(port int, h health.IHealth) *server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, handlers.NewJSONHandlerFunc(h, nil))
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	return &server{
+		port:       port,
+		httpServer: &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux},
+	}
+}
+
+func (s *server) Port() int {
+	return s.port
+}
+
+// start serving in goroutine, used with micro.AfterStart before registering service in consul
+func (s *server) Start() error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return errors.New(fmt.Sprintf("unable to listen health check port, port: %d, err: %v", s.port, err))
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Errorf("health check server stopped unexpectedly, err: %v", err)
+		}
+	}()
+	log.Infof("start serving health check!! (port: %d, path: %s)", s.port, Path)
+	return nil
+}
+
+// stop serving after in-flight health check requests are finished
+func (s *server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStopTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
@@ -34,7 +34,8 @@ func ParseDuplicateEntryErrorFrom(mysqlErr *mysql.MySQLError) (key, entry string
 		matched[i] = strings.Trim(matched[i], "'")
 	}
 
-	key = matched[indexKey]
+	// MySQL 8.0.19 or later reports key with table name like 'clubs.leader_uuid'
+	key = matched[indexKey][strings.LastIndex(matched[indexKey], ".")+1:]
 	entry = matched[indexEntry]
 	return
 }
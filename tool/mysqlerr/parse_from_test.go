@@ -0,0 +1,44 @@
+package mysqlerr
+
+import (
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ParseDuplicateEntryErrorFrom(t *testing.T) {
+	tests := []struct {
+		Error         *mysql.MySQLError
+		ExpectedKey   string
+		ExpectedEntry string
+		ExpectError   bool
+	}{
+		{ // key without table name (before MySQL 8.0.19)
+			Error:         &mysql.MySQLError{Number: mysqlerr.ER_DUP_ENTRY, Message: "Duplicate entry 'student-111111111111' for key 'leader_uuid'"},
+			ExpectedKey:   "leader_uuid",
+			ExpectedEntry: "student-111111111111",
+		}, { // key with table name (MySQL 8.0.19 or later)
+			Error:         &mysql.MySQLError{Number: mysqlerr.ER_DUP_ENTRY, Message: "Duplicate entry 'student-111111111111-1' for key 'clubs.leader_uuid'"},
+			ExpectedKey:   "leader_uuid",
+			ExpectedEntry: "student-111111111111-1",
+		}, { // entry including '.' is not changed
+			Error:         DuplicateEntry("student_uuid", "club-111111111111.student-111111111111"),
+			ExpectedKey:   "student_uuid",
+			ExpectedEntry: "club-111111111111.student-111111111111",
+		}, { // not duplicate entry error
+			Error:       &mysql.MySQLError{Number: mysqlerr.ER_LOCK_DEADLOCK, Message: "Deadlock found when trying to get lock; try restarting transaction"},
+			ExpectError: true,
+		}, { // invalid format
+			Error:       &mysql.MySQLError{Number: mysqlerr.ER_DUP_ENTRY, Message: "Duplicate entry for key 'name'"},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		key, entry, err := ParseDuplicateEntryErrorFrom(test.Error)
+		assert.Equalf(t, test.ExpectError, err != nil, "error assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedKey, key, "key assertion error (test case: %v)", test)
+		assert.Equalf(t, test.ExpectedEntry, entry, "entry assertion error (test case: %v)", test)
+	}
+}
@@ -0,0 +1,22 @@
+package mysqlerr
+
+import (
+	"errors"
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+)
+
+// IsRetryable returns whether transaction failed with err may succeed if whole transaction is run again
+// deadlock & lock wait timeout are caused by concurrent transactions, so they are not a fault of transaction itself
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	switch mysqlErr.Number {
+	case mysqlerr.ER_LOCK_DEADLOCK, mysqlerr.ER_LOCK_WAIT_TIMEOUT:
+		return true
+	}
+	return false
+}